@@ -0,0 +1,76 @@
+package semver
+
+// The operations in this file treat a [Range] as the set of versions that
+// satisfy it, and build a new range from the result. Every result is
+// normalized into a list of comparator sets in ascending order, and renders
+// via [Range.String] as a range string that can be parsed again. A result
+// that does not contain any versions renders as `<0.0.0-0`.
+//
+// The pre-release rule described by [Version.Satisfies] is kept: a
+// pre-release satisfies the result of [Range.Union], [Range.Intersect], or
+// [Range.Difference] exactly when it satisfies the inputs accordingly. This
+// can not be written down for a range that allows the pre-releases of any
+// tuple, i.e. one parsed with [Options.IncludePrerelease] or written in a
+// dialect with its own pre-release rule. When either input is such a range,
+// both are treated as the versions they cover when ordered by precedence,
+// and the result is evaluated with [Options.IncludePrerelease].
+
+// Union returns a new range that covers every version covered by either
+// the range or the other range, e.g. the union of `^1.0.0` and `1.5.0-beta`
+// is `>=1.0.0 <2.0.0-0 || =1.5.0-beta`.
+func (r *Range) Union(other *Range) *Range {
+	return combineRanges(r, other, func(a []Interval, b []Interval) []Interval {
+		return normalizeIntervals(append(a, b...))
+	})
+}
+
+// Intersect returns a new range that covers only the versions covered by
+// both the range and the other range, e.g. the intersection of `^1.0.0`
+// and `>=1.5.0-beta` is `>=1.5.0 <2.0.0-0`, since `^1.0.0` is not satisfied
+// by the pre-releases of `1.5.0`.
+func (r *Range) Intersect(other *Range) *Range {
+	return combineRanges(r, other, intersectIntervals)
+}
+
+// Complement returns a new range that covers every version not covered by
+// the range.
+//
+// Unlike the other operations, the complement treats the range as the
+// versions it covers when ordered by precedence, since the pre-releases
+// that do not satisfy a range span every tuple. For example, the complement
+// of `^1.2.3` is `<1.2.3 || >=2.0.0-0`, which is not satisfied by
+// `1.5.0-beta` even though `^1.2.3` is not either.
+func (r *Range) Complement() *Range {
+	return rangeFromIntervals(complementIntervals(r.Intervals()))
+}
+
+// Difference returns a new range that covers the versions covered by the
+// range but not by the other range, e.g. an organization baseline minus
+// known bad versions.
+func (r *Range) Difference(other *Range) *Range {
+	return combineRanges(r, other, func(a []Interval, b []Interval) []Interval {
+		return intersectIntervals(a, complementIntervals(b))
+	})
+}
+
+// combineRanges applies the operation to the releases covered by both
+// ranges, and to the pre-releases of every tuple either range opts in to,
+// and builds the range that covers the results.
+func combineRanges(r *Range, other *Range, op func(a []Interval, b []Interval) []Interval) *Range {
+	releases := op(r.Intervals(), other.Intervals())
+	if r.options.IncludePrerelease == true || other.options.IncludePrerelease == true ||
+		r.hasPrereleasePolicy() == true || other.hasPrereleasePolicy() == true {
+		result := rangeFromIntervals(releases)
+		result.options = Options{IncludePrerelease: true}
+		return result
+	}
+
+	sets := append(append([]ComparatorSet{}, r.comparators...), other.comparators...)
+	prereleases := make([]Interval, 0)
+	for _, tuple := range prereleaseTuples(sets) {
+		a := prereleaseIntervals(r.comparators, tuple)
+		b := prereleaseIntervals(other.comparators, tuple)
+		prereleases = append(prereleases, op(a, b)...)
+	}
+	return rangeFromVersions(releases, prereleases)
+}
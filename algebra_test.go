@@ -0,0 +1,304 @@
+package semver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRange_Algebra(t *testing.T) {
+	testCases := []struct {
+		title    string
+		a        string
+		b        string
+		op       func(a *Range, b *Range) *Range
+		expected string
+	}{
+		// Union:
+		{
+			title:    "union: disjoint",
+			a:        "^2.0.0",
+			b:        "^1.0.0",
+			op:       (*Range).Union,
+			expected: ">=1.0.0 <2.0.0-0 || >=2.0.0 <3.0.0-0",
+		},
+		{
+			title:    "union: overlapping",
+			a:        ">=1.0.0 <1.5.0",
+			b:        ">=1.2.0 <2.0.0",
+			op:       (*Range).Union,
+			expected: ">=1.0.0 <2.0.0",
+		},
+		{
+			title:    "union: adjacent",
+			a:        ">=1.0.0 <1.5.0",
+			b:        ">=1.5.0 <2.0.0",
+			op:       (*Range).Union,
+			expected: ">=1.0.0 <2.0.0",
+		},
+		{
+			title:    "union: exclusive on both sides leaves a gap",
+			a:        ">=1.0.0 <1.5.0",
+			b:        ">1.5.0 <2.0.0",
+			op:       (*Range).Union,
+			expected: ">=1.0.0 <1.5.0 || >1.5.0 <2.0.0",
+		},
+		{
+			title:    "union: exact version closes gap",
+			a:        ">=1.0.0 <1.5.0 || >1.5.0 <2.0.0",
+			b:        "1.5.0",
+			op:       (*Range).Union,
+			expected: ">=1.0.0 <2.0.0",
+		},
+		{
+			title:    "union: unbounded",
+			a:        "<1.0.0",
+			b:        ">=0.5.0",
+			op:       (*Range).Union,
+			expected: ">=0.0.0",
+		},
+
+		// Intersect:
+		{
+			title:    "intersect: overlapping",
+			a:        "^1.2.0",
+			b:        ">=1.5.0 <3.0.0",
+			op:       (*Range).Intersect,
+			expected: ">=1.5.0 <2.0.0-0",
+		},
+		{
+			title:    "intersect: multiple sets",
+			a:        "^1.0.0 || ^3.0.0",
+			b:        ">=1.5.0 <3.5.0",
+			op:       (*Range).Intersect,
+			expected: ">=1.5.0 <2.0.0-0 || >=3.0.0 <3.5.0",
+		},
+		{
+			title:    "intersect: single version",
+			a:        ">=1.0.0 <=1.5.0",
+			b:        ">=1.5.0",
+			op:       (*Range).Intersect,
+			expected: "=1.5.0",
+		},
+		{
+			title:    "intersect: disjoint",
+			a:        "^1.0.0",
+			b:        "^2.0.0",
+			op:       (*Range).Intersect,
+			expected: "<0.0.0-0",
+		},
+
+		// Difference:
+		{
+			title:    "difference: known bad version",
+			a:        "^2.0.0",
+			b:        "2.3.1",
+			op:       (*Range).Difference,
+			expected: ">=2.0.0 <2.3.1 || >2.3.1 <3.0.0-0",
+		},
+		{
+			title:    "difference: baseline and override minus bad versions",
+			a:        ">=1.0.0 <3.0.0",
+			b:        ">=1.4.0 <1.6.0 || >=2.0.0",
+			op:       (*Range).Difference,
+			expected: ">=1.0.0 <1.4.0 || >=1.6.0 <2.0.0",
+		},
+		{
+			title:    "difference: everything removed",
+			a:        "^1.2.0",
+			b:        "1.x",
+			op:       (*Range).Difference,
+			expected: "<0.0.0-0",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			a, err := RangeFromString(testCase.a)
+			assert.Nil(t, err)
+			b, err := RangeFromString(testCase.b)
+			assert.Nil(t, err)
+
+			found := testCase.op(a, b)
+			assert.Equal(t, testCase.expected, found.String())
+
+			reparsed, err := RangeFromString(found.String())
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, reparsed.String())
+		})
+	}
+}
+
+func TestRange_Algebra_Prereleases(t *testing.T) {
+	// A pre-release satisfies the result exactly when it satisfies the
+	// inputs accordingly.
+	testCases := []struct {
+		title    string
+		a        string
+		b        string
+		op       func(a *Range, b *Range) *Range
+		version  string
+		expected string
+		inA      bool
+		inB      bool
+		inResult bool
+	}{
+		{
+			title:    "union",
+			a:        "^1.0.0",
+			b:        "1.5.0-beta",
+			op:       (*Range).Union,
+			version:  "1.5.0-beta",
+			expected: ">=1.0.0 <2.0.0-0 || =1.5.0-beta",
+			inA:      false,
+			inB:      true,
+			inResult: true,
+		},
+		{
+			title:    "union: any version",
+			a:        "*",
+			b:        "^1.2.3-beta.1",
+			op:       (*Range).Union,
+			version:  "1.2.3-beta.1",
+			expected: ">=0.0.0 || >=1.2.3-beta.1 <1.2.3",
+			inA:      false,
+			inB:      true,
+			inResult: true,
+		},
+		{
+			title:    "union: overlapping",
+			a:        ">=1.2.3-rc.1 <2",
+			b:        "~1.2",
+			op:       (*Range).Union,
+			version:  "1.2.3-rc.1",
+			expected: ">=1.2.0 <2.0.0 || >=1.2.3-rc.1 <1.2.3",
+			inA:      true,
+			inB:      false,
+			inResult: true,
+		},
+		{
+			title:    "union: pre-releases become a bound",
+			a:        "^1.2.3-beta.1",
+			b:        "^1.2.3",
+			op:       (*Range).Union,
+			version:  "1.2.3-beta.2",
+			expected: ">=1.2.3-beta.1 <2.0.0-0",
+			inA:      true,
+			inB:      false,
+			inResult: true,
+		},
+		{
+			title:    "union: pre-releases a partial != does not exclude",
+			a:        "*",
+			b:        ">=1.2.5-beta !=1.2",
+			op:       (*Range).Union,
+			version:  "1.2.5-beta",
+			expected: ">=0.0.0 || >=1.2.5-beta <1.2.5",
+			inA:      false,
+			inB:      true,
+			inResult: true,
+		},
+		{
+			title:    "intersect",
+			a:        "^1.0.0",
+			b:        ">=1.5.0-beta",
+			op:       (*Range).Intersect,
+			version:  "1.5.0-rc",
+			expected: ">=1.5.0 <2.0.0-0",
+			inA:      false,
+			inB:      true,
+			inResult: false,
+		},
+		{
+			title:    "intersect: both opt in",
+			a:        "^1.5.0-alpha",
+			b:        ">=1.5.0-beta",
+			op:       (*Range).Intersect,
+			version:  "1.5.0-rc",
+			expected: ">=1.5.0-beta <2.0.0-0",
+			inA:      true,
+			inB:      true,
+			inResult: true,
+		},
+		{
+			title:    "difference",
+			a:        "^1.0.0",
+			b:        "<1.5.0-beta",
+			op:       (*Range).Difference,
+			version:  "1.5.0-beta",
+			expected: ">=1.5.0 <2.0.0-0",
+			inA:      false,
+			inB:      false,
+			inResult: false,
+		},
+		{
+			title:    "difference: pre-releases are removed",
+			a:        ">=1.5.0-alpha <2.0.0",
+			b:        "<1.5.0-beta",
+			op:       (*Range).Difference,
+			version:  "1.5.0-beta",
+			expected: ">=1.5.0-beta <2.0.0",
+			inA:      true,
+			inB:      false,
+			inResult: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			a, err := RangeFromString(testCase.a)
+			assert.Nil(t, err)
+			b, err := RangeFromString(testCase.b)
+			assert.Nil(t, err)
+			v, err := VersionFromString(testCase.version)
+			assert.Nil(t, err)
+
+			found := testCase.op(a, b)
+			assert.Equal(t, testCase.expected, found.String())
+			assert.Equal(t, testCase.inA, v.Satisfies(a))
+			assert.Equal(t, testCase.inB, v.Satisfies(b))
+			assert.Equal(t, testCase.inResult, v.Satisfies(found))
+
+			reparsed, err := RangeFromString(found.String())
+			assert.Nil(t, err)
+			assert.Equal(t, true, found.Equivalent(reparsed))
+		})
+	}
+
+	t.Run("include pre-release", func(t *testing.T) {
+		a, _ := RangeFromStringWithOptions("^1.0.0", Options{IncludePrerelease: true})
+		b, _ := RangeFromString("^3.0.0")
+		v, _ := VersionFromString("1.5.0-beta")
+
+		found := a.Union(b)
+		assert.Equal(t, ">=1.0.0 <2.0.0-0 || >=3.0.0 <4.0.0-0", found.String())
+		assert.Equal(t, true, v.Satisfies(found))
+	})
+}
+
+func TestRange_Complement(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{input: "^1.2.3", expected: "<1.2.3 || >=2.0.0-0"},
+		{input: "<1.0.0 || >2.0.0", expected: ">=1.0.0 <=2.0.0"},
+		{input: "1.2.3", expected: "<1.2.3 || >1.2.3"},
+		{input: "<0.0.0-0", expected: ">=0.0.0-0"},
+		{input: ">=0.0.0-0", expected: "<0.0.0-0"},
+		{input: "*", expected: "<0.0.0"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			r, err := RangeFromString(testCase.input)
+			assert.Nil(t, err)
+
+			found := r.Complement()
+			assert.Equal(t, testCase.expected, found.String())
+
+			reparsed, err := RangeFromString(found.String())
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, reparsed.String())
+		})
+	}
+}
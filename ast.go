@@ -0,0 +1,402 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Span locates a node within the source string of a parsed range. Start and
+// End are byte offsets, with End being exclusive, such that
+// `source[span.Start:span.End]` is the text the node was parsed from.
+type Span struct {
+	Start int
+	End   int
+}
+
+// Node is implemented by every node of a range abstract syntax tree as
+// produced by [ParseAST].
+type Node interface {
+	// Span returns the location of the node within the parsed source.
+	Span() Span
+	// String renders the node in range syntax.
+	String() string
+}
+
+// TermNode is implemented by the nodes that may appear as the terms of a
+// [SetNode]: [HyphenNode], [TildeNode], [CaretNode], [XRangeNode] and
+// [PrimitiveNode].
+type TermNode interface {
+	Node
+	termNode()
+}
+
+// RangeNode is the root of a range abstract syntax tree. It holds one
+// [SetNode] for every set separated by `||` in the source.
+type RangeNode struct {
+	Source string
+	Sets   []*SetNode
+
+	span Span
+}
+
+// SetNode is a set of terms separated by whitespace. A version must satisfy
+// every term of the set in order to satisfy the set. An empty set matches
+// any version.
+type SetNode struct {
+	Terms []TermNode
+
+	span Span
+}
+
+// HyphenNode represents an inclusive hyphen range, e.g. `1.2.3 - 2.3.4`.
+type HyphenNode struct {
+	From *Version
+	To   *Version
+
+	span Span
+}
+
+// TildeNode represents a tilde range, e.g. `~1.2.3`.
+type TildeNode struct {
+	Version *Version
+
+	span Span
+}
+
+// CaretNode represents a caret range, e.g. `^1.2.3`.
+type CaretNode struct {
+	Version *Version
+
+	span Span
+}
+
+// XRangeNode represents a version without an operator. The version may be
+// partial, e.g. `1.x` or `1.2`, or complete, e.g. `1.2.3`.
+type XRangeNode struct {
+	Version *Version
+
+	span Span
+}
+
+// PrimitiveNode represents a version prefixed with one of the basic
+// operators, e.g. `>=1.2.3`.
+type PrimitiveNode struct {
+	Operator RangeOperator
+	Version  *Version
+
+	span Span
+}
+
+func (n *RangeNode) Span() Span     { return n.span }
+func (n *SetNode) Span() Span       { return n.span }
+func (n *HyphenNode) Span() Span    { return n.span }
+func (n *TildeNode) Span() Span     { return n.span }
+func (n *CaretNode) Span() Span     { return n.span }
+func (n *XRangeNode) Span() Span    { return n.span }
+func (n *PrimitiveNode) Span() Span { return n.span }
+
+func (*HyphenNode) termNode()    {}
+func (*TildeNode) termNode()     {}
+func (*CaretNode) termNode()     {}
+func (*XRangeNode) termNode()    {}
+func (*PrimitiveNode) termNode() {}
+
+func (n *RangeNode) String() string {
+	sets := make([]string, 0, len(n.Sets))
+	for _, set := range n.Sets {
+		sets = append(sets, set.String())
+	}
+	return strings.Join(sets, " || ")
+}
+
+func (n *SetNode) String() string {
+	terms := make([]string, 0, len(n.Terms))
+	for _, term := range n.Terms {
+		terms = append(terms, term.String())
+	}
+	return strings.Join(terms, " ")
+}
+
+func (n *HyphenNode) String() string {
+	return fmt.Sprintf("%s - %s", versionSyntax(n.From), versionSyntax(n.To))
+}
+
+func (n *TildeNode) String() string {
+	return "~" + versionSyntax(n.Version)
+}
+
+func (n *CaretNode) String() string {
+	return "^" + versionSyntax(n.Version)
+}
+
+func (n *XRangeNode) String() string {
+	return versionSyntax(n.Version)
+}
+
+func (n *PrimitiveNode) String() string {
+	return n.Operator.String() + versionSyntax(n.Version)
+}
+
+// sourceSets returns the source text of every set of the tree.
+func (n *RangeNode) sourceSets() []string {
+	sets := make([]string, 0, len(n.Sets))
+	for _, set := range n.Sets {
+		sets = append(sets, n.Source[set.span.Start:set.span.End])
+	}
+	return sets
+}
+
+// versionSyntax renders a version as it would be written in a range, i.e.
+// only the components that were parsed are included. A version without any
+// parsed component is rendered as `*`.
+func versionSyntax(v *Version) string {
+	if v == nil || v.majorParsed == false {
+		return "*"
+	}
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("%d", v.major))
+	if v.minorParsed == true {
+		builder.WriteString(fmt.Sprintf(".%d", v.minor))
+	}
+	if v.patchParsed == true {
+		builder.WriteString(fmt.Sprintf(".%d", v.patch))
+	}
+	if v.pre != "" {
+		builder.WriteString(fmt.Sprintf("-%s", v.pre))
+	}
+	if v.build != "" {
+		builder.WriteString(fmt.Sprintf("+%s", v.build))
+	}
+	return builder.String()
+}
+
+// ParseAST parses a range string into an abstract syntax tree that retains
+// the original syntax of every term. The tree can be inspected, or altered,
+// and then turned into a [Range] with [RangeNode.Desugar].
+func ParseAST(input string) (*RangeNode, error) {
+	root := &RangeNode{
+		Source: input,
+		Sets:   make([]*SetNode, 0),
+		span:   Span{Start: 0, End: len(input)},
+	}
+
+	start := 0
+	for {
+		end := strings.Index(input[start:], "||")
+		if end < 0 {
+			end = len(input)
+		} else {
+			end += start
+		}
+
+		set, err := parseSetNode(input, start, end)
+		if err != nil {
+			return nil, err
+		}
+		root.Sets = append(root.Sets, set)
+
+		if end == len(input) {
+			break
+		}
+		start = end + 2
+	}
+
+	return root, nil
+}
+
+// token is a whitespace delimited piece of a set along with its location in
+// the source.
+type token struct {
+	text string
+	span Span
+}
+
+// tokenize splits `input[start:end]` on whitespace. Tokens that consist
+// solely of operator characters, e.g. the `>=` in `>= 1.2.3`, are joined
+// with the token that follows them.
+func tokenize(input string, start int, end int) []token {
+	tokens := make([]token, 0)
+	for i := start; i < end; {
+		if isWhitespace(input[i]) == true {
+			i += 1
+			continue
+		}
+		j := i
+		for j < end && isWhitespace(input[j]) == false {
+			j += 1
+		}
+		tokens = append(tokens, token{text: input[i:j], span: Span{Start: i, End: j}})
+		i = j
+	}
+
+	merged := make([]token, 0, len(tokens))
+	for i := 0; i < len(tokens); i += 1 {
+		tok := tokens[i]
+		for isOperatorOnly(tok.text) == true && i+1 < len(tokens) {
+			i += 1
+			tok = token{
+				text: tok.text + tokens[i].text,
+				span: Span{Start: tok.span.Start, End: tokens[i].span.End},
+			}
+		}
+		merged = append(merged, tok)
+	}
+	return merged
+}
+
+func isWhitespace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
+
+func isOperatorOnly(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i += 1 {
+		if isOperatorChar(s[i]) == false && s[i] != '~' && s[i] != '^' {
+			return false
+		}
+	}
+	return true
+}
+
+func parseSetNode(input string, start int, end int) (*SetNode, error) {
+	tokens := tokenize(input, start, end)
+	set := &SetNode{Terms: make([]TermNode, 0, len(tokens))}
+	if len(tokens) == 0 {
+		trimmed := strings.TrimLeft(input[start:end], " \t\n\r")
+		pos := end - len(trimmed)
+		set.span = Span{Start: pos, End: pos}
+		return set, nil
+	}
+	set.span = Span{Start: tokens[0].span.Start, End: tokens[len(tokens)-1].span.End}
+
+	for _, tok := range tokens {
+		if tok.text == "-" {
+			hyphen, err := parseHyphenNode(input, tokens)
+			if err != nil {
+				return nil, err
+			}
+			set.Terms = append(set.Terms, hyphen)
+			return set, nil
+		}
+	}
+
+	for _, tok := range tokens {
+		term, err := parseTermNode(tok)
+		if err != nil {
+			return nil, err
+		}
+		set.Terms = append(set.Terms, term)
+	}
+	return set, nil
+}
+
+func parseHyphenNode(input string, tokens []token) (*HyphenNode, error) {
+	span := Span{Start: tokens[0].span.Start, End: tokens[len(tokens)-1].span.End}
+	if len(tokens) != 3 || tokens[1].text != "-" {
+		return nil, fmt.Errorf("%w: hyphen range `%s` must be of the form `X - Y`", ErrRangeSyntax, input[span.Start:span.End])
+	}
+
+	from, err := parseTermVersion(tokens[0].text)
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseTermVersion(tokens[2].text)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HyphenNode{From: from, To: to, span: span}, nil
+}
+
+func parseTermNode(tok token) (TermNode, error) {
+	switch {
+	case strings.HasPrefix(tok.text, "~"):
+		// `~>` is accepted as an alias of `~`.
+		ver, err := parseTermVersion(strings.TrimPrefix(tok.text[1:], ">"))
+		if err != nil {
+			return nil, err
+		}
+		return &TildeNode{Version: ver, span: tok.span}, nil
+	case strings.HasPrefix(tok.text, "^"):
+		ver, err := parseTermVersion(tok.text[1:])
+		if err != nil {
+			return nil, err
+		}
+		return &CaretNode{Version: ver, span: tok.span}, nil
+	}
+
+	c, err := parseComparator([]byte(tok.text))
+	if err != nil {
+		return nil, err
+	}
+	if c.version == nil {
+		return nil, fmt.Errorf("%w: `%s` is missing a version", ErrRangeSyntax, tok.text)
+	}
+	if c.parsedOperator == true {
+		return &PrimitiveNode{Operator: c.operator, Version: c.version, span: tok.span}, nil
+	}
+	return &XRangeNode{Version: c.version, span: tok.span}, nil
+}
+
+// parseTermVersion parses the version of a term that does not allow an
+// operator, e.g. either side of a hyphen range.
+func parseTermVersion(input string) (*Version, error) {
+	c, err := parseComparator([]byte(input))
+	if err != nil {
+		return nil, err
+	}
+	if c.parsedOperator == true {
+		return nil, fmt.Errorf("%w: unexpected operator in `%s`", ErrRangeSyntax, input)
+	}
+	if c.version == nil {
+		return nil, fmt.Errorf("%w: `%s` is missing a version", ErrRangeSyntax, input)
+	}
+	return c.version, nil
+}
+
+// Visitor is invoked by [Walk] for every node of a range abstract syntax
+// tree. If the result visitor w is not nil, [Walk] visits each of the
+// children of node with the visitor w, followed by a call of w.Visit(nil).
+type Visitor interface {
+	Visit(node Node) (w Visitor)
+}
+
+// Walk traverses a range abstract syntax tree in depth-first order. It
+// starts by calling v.Visit(node); node must not be nil.
+func Walk(v Visitor, node Node) {
+	if v = v.Visit(node); v == nil {
+		return
+	}
+
+	switch n := node.(type) {
+	case *RangeNode:
+		for _, set := range n.Sets {
+			Walk(v, set)
+		}
+	case *SetNode:
+		for _, term := range n.Terms {
+			Walk(v, term)
+		}
+	}
+
+	v.Visit(nil)
+}
+
+type inspector func(Node) bool
+
+func (f inspector) Visit(node Node) Visitor {
+	if f(node) == true {
+		return f
+	}
+	return nil
+}
+
+// Inspect traverses a range abstract syntax tree in depth-first order. It
+// starts by calling f(node); node must not be nil. If f returns true,
+// Inspect invokes f for each of the children of node, followed by a call of
+// f(nil).
+func Inspect(node Node, f func(Node) bool) {
+	Walk(inspector(f), node)
+}
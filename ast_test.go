@@ -0,0 +1,216 @@
+package semver
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseAST(t *testing.T) {
+	testCases := []struct {
+		title    string
+		input    string
+		expected []string
+	}{
+		{
+			title:    "primitive",
+			input:    ">=1.2.3",
+			expected: []string{"*semver.PrimitiveNode >=1.2.3 [0:7]"},
+		},
+		{
+			title:    "x-range",
+			input:    "1.x",
+			expected: []string{"*semver.XRangeNode 1 [0:3]"},
+		},
+		{
+			title:    "any version",
+			input:    "*",
+			expected: []string{"*semver.XRangeNode * [0:1]"},
+		},
+		{
+			title:    "tilde",
+			input:    "~1.2",
+			expected: []string{"*semver.TildeNode ~1.2 [0:4]"},
+		},
+		{
+			title:    "caret with pre-release",
+			input:    "^1.2.3-beta.2",
+			expected: []string{"*semver.CaretNode ^1.2.3-beta.2 [0:13]"},
+		},
+		{
+			title:    "hyphen",
+			input:    "1.2 - 2.3.4",
+			expected: []string{"*semver.HyphenNode 1.2 - 2.3.4 [0:11]"},
+		},
+		{
+			title: "several sets and terms",
+			input: "^1.2.3 <1.5.0 || >= 2.0.0",
+			expected: []string{
+				"*semver.CaretNode ^1.2.3 [0:6]",
+				"*semver.PrimitiveNode <1.5.0 [7:13]",
+				"*semver.PrimitiveNode >=2.0.0 [17:25]",
+			},
+		},
+		{
+			title:    "empty set",
+			input:    "",
+			expected: []string{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			root, err := ParseAST(testCase.input)
+			assert.Nil(t, err)
+
+			found := make([]string, 0)
+			for _, set := range root.Sets {
+				for _, term := range set.Terms {
+					span := term.Span()
+					found = append(found, fmt.Sprintf("%T %s [%d:%d]", term, term, span.Start, span.End))
+				}
+			}
+			assert.Equal(t, testCase.expected, found)
+		})
+	}
+
+	t.Run("spans locate the source text", func(t *testing.T) {
+		input := " ~1.2.3 ||  >=  1.0.0 <2 "
+		root, err := ParseAST(input)
+		assert.Nil(t, err)
+		assert.Equal(t, "~1.2.3", input[root.Sets[0].Span().Start:root.Sets[0].Span().End])
+		assert.Equal(t, ">=  1.0.0 <2", input[root.Sets[1].Span().Start:root.Sets[1].Span().End])
+		term := root.Sets[1].Terms[0]
+		assert.Equal(t, ">=  1.0.0", input[term.Span().Start:term.Span().End])
+		assert.Equal(t, "~1.2.3 || >=1.0.0 <2", root.String())
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := ParseAST("1.0.0 - 2.0.0 - 3.0.0")
+		assert.ErrorIs(t, err, ErrRangeSyntax)
+
+		_, err = ParseAST("1.0.0 <")
+		assert.ErrorIs(t, err, ErrRangeSyntax)
+
+		_, err = ParseAST(">=1.0.0 - 2.0.0")
+		assert.ErrorIs(t, err, ErrRangeSyntax)
+
+		_, err = ParseAST("^A.1.0")
+		assert.ErrorIs(t, err, ErrRangeAlpha)
+	})
+}
+
+func TestRangeNode_Desugar(t *testing.T) {
+	testCases := []struct {
+		title    string
+		input    string
+		expected string
+	}{
+		{
+			title:    "caret with primitive",
+			input:    "^1.2.3 <1.5.0",
+			expected: ">=1.2.3 <2.0.0-0 <1.5.0",
+		},
+		{
+			title:    "x-range within several terms",
+			input:    "1.x >=1.2.0",
+			expected: ">=1.0.0 <2.0.0 >=1.2.0",
+		},
+		{
+			title:    "tilde and hyphen sets",
+			input:    "~1.2 || 2 - 3",
+			expected: ">=1.2.0 <1.3.0-0 || >=2.0.0 <4.0.0",
+		},
+		{
+			title:    "empty set",
+			input:    "1.2.3 ||",
+			expected: "=1.2.3 || >=0.0.0",
+		},
+		{
+			title:    "hyphen without an upper bound",
+			input:    "1.2.3 - *",
+			expected: ">=1.2.3",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			root, err := ParseAST(testCase.input)
+			assert.Nil(t, err)
+			rng, err := root.Desugar(Options{})
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, rng.String())
+		})
+	}
+
+	t.Run("nodes can be rewritten before desugaring", func(t *testing.T) {
+		root, err := ParseAST("^1.2.3 || ~2.0")
+		assert.Nil(t, err)
+
+		floor, _ := VersionFromString("1.4.0")
+		Inspect(root, func(node Node) bool {
+			if caret, ok := node.(*CaretNode); ok == true {
+				caret.Version = floor
+			}
+			return true
+		})
+		assert.Equal(t, "^1.4.0 || ~2.0", root.String())
+
+		rng, err := root.Desugar(Options{})
+		assert.Nil(t, err)
+		assert.Equal(t, ">=1.4.0 <2.0.0-0 || >=2.0.0 <2.1.0-0", rng.String())
+		assert.Equal(t, "1.4.0", floor.String())
+	})
+
+	t.Run("unsatisfiable sets are rejected", func(t *testing.T) {
+		root, err := ParseAST("^2 <1.0.0")
+		assert.Nil(t, err)
+		rng, err := root.Desugar(Options{RejectUnsatisfiable: true})
+		assert.Nil(t, rng)
+		assert.ErrorIs(t, err, ErrRangeUnsatisfiable)
+	})
+}
+
+type countingVisitor struct {
+	entered []string
+	exits   int
+}
+
+func (v *countingVisitor) Visit(node Node) Visitor {
+	if node == nil {
+		v.exits += 1
+		return nil
+	}
+	v.entered = append(v.entered, fmt.Sprintf("%T", node))
+	return v
+}
+
+func TestWalk(t *testing.T) {
+	root, err := ParseAST("1.2.3 - 2 || >1 ^2.0.0")
+	assert.Nil(t, err)
+
+	v := &countingVisitor{}
+	Walk(v, root)
+	assert.Equal(t, []string{
+		"*semver.RangeNode",
+		"*semver.SetNode",
+		"*semver.HyphenNode",
+		"*semver.SetNode",
+		"*semver.PrimitiveNode",
+		"*semver.CaretNode",
+	}, v.entered)
+	assert.Equal(t, 6, v.exits)
+
+	t.Run("inspect can prune the traversal", func(t *testing.T) {
+		count := 0
+		Inspect(root, func(node Node) bool {
+			if node != nil {
+				count += 1
+			}
+			_, isSet := node.(*SetNode)
+			return isSet == false
+		})
+		// The root and both sets, but none of the terms.
+		assert.Equal(t, 3, count)
+	})
+}
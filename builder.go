@@ -0,0 +1,132 @@
+package semver
+
+import (
+	"fmt"
+)
+
+// RangeBuilder assembles a [Range] from versions without parsing a range
+// string. Comparators are added to the current comparator set until
+// [RangeBuilder.Or] starts a new one, e.g.:
+//
+//	r, err := NewRange().
+//		GreaterThanEqual(floor).LessThan(ceiling).NotEqual(bad).
+//		Or().
+//		Equal(pinned).
+//		Build()
+//
+// Build metadata is dropped from the provided versions, as it does not factor
+// into precedence, and partial versions are zero filled.
+type RangeBuilder struct {
+	sets    []ComparatorSet
+	current []*Comparator
+	options Options
+	err     error
+}
+
+// NewRange starts building a new [Range].
+func NewRange() *RangeBuilder {
+	return &RangeBuilder{
+		sets:    make([]ComparatorSet, 0),
+		current: make([]*Comparator, 0),
+	}
+}
+
+// Comparator adds a comparator with the provided operator to the current
+// comparator set.
+func (b *RangeBuilder) Comparator(operator RangeOperator, v *Version) *RangeBuilder {
+	if b.err != nil {
+		return b
+	}
+	if operator.isValid() == false {
+		b.err = fmt.Errorf("%w: `%s`", ErrRangeOperator, operator)
+		return b
+	}
+	if v == nil {
+		b.err = fmt.Errorf("%w: comparator `%s` is missing a version", ErrRangeSyntax, operator)
+		return b
+	}
+	b.current = append(b.current, &Comparator{
+		operator: operator,
+		version:  newVersion(v.major, v.minor, v.patch, v.pre),
+	})
+	return b
+}
+
+// Equal adds an `=` comparator to the current comparator set.
+func (b *RangeBuilder) Equal(v *Version) *RangeBuilder {
+	return b.Comparator(OperatorEqual, v)
+}
+
+// NotEqual adds a `!=` comparator to the current comparator set.
+func (b *RangeBuilder) NotEqual(v *Version) *RangeBuilder {
+	return b.Comparator(OperatorNotEqual, v)
+}
+
+// LessThan adds a `<` comparator to the current comparator set.
+func (b *RangeBuilder) LessThan(v *Version) *RangeBuilder {
+	return b.Comparator(OperatorLessThan, v)
+}
+
+// LessThanEqual adds a `<=` comparator to the current comparator set.
+func (b *RangeBuilder) LessThanEqual(v *Version) *RangeBuilder {
+	return b.Comparator(OperatorLessThanEqual, v)
+}
+
+// GreaterThan adds a `>` comparator to the current comparator set.
+func (b *RangeBuilder) GreaterThan(v *Version) *RangeBuilder {
+	return b.Comparator(OperatorGreaterThan, v)
+}
+
+// GreaterThanEqual adds a `>=` comparator to the current comparator set.
+func (b *RangeBuilder) GreaterThanEqual(v *Version) *RangeBuilder {
+	return b.Comparator(OperatorGreaterThanEqual, v)
+}
+
+// Or completes the current comparator set and starts a new one, i.e. it
+// inserts a `||` into the range. A comparator set without any comparators
+// matches any version, just like an empty set in a range string.
+func (b *RangeBuilder) Or() *RangeBuilder {
+	b.sets = append(b.sets, b.currentSet())
+	b.current = make([]*Comparator, 0)
+	return b
+}
+
+// WithOptions sets the [Options] the built range is evaluated with.
+func (b *RangeBuilder) WithOptions(opts Options) *RangeBuilder {
+	b.options = opts
+	return b
+}
+
+// Build completes the current comparator set and returns the range. An
+// error is returned if any comparator was invalid, or, when built with
+// [Options.RejectUnsatisfiable], if any comparator set can never be
+// satisfied.
+func (b *RangeBuilder) Build() (*Range, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	sets := make([]ComparatorSet, 0, len(b.sets)+1)
+	sets = append(sets, b.sets...)
+	sets = append(sets, b.currentSet())
+
+	if b.options.RejectUnsatisfiable == true {
+		for _, set := range sets {
+			reason := unsatisfiableReason(set, b.options)
+			if reason != "" {
+				return nil, fmt.Errorf("%w: comparator set `%s` is empty because %s", ErrRangeUnsatisfiable, set, reason)
+			}
+		}
+	}
+
+	return &Range{comparators: sets, options: b.options}, nil
+}
+
+func (b *RangeBuilder) currentSet() ComparatorSet {
+	if len(b.current) == 0 {
+		return newComparatorSet(anyVersionComparator())
+	}
+	comparators := make([]*Comparator, len(b.current))
+	copy(comparators, b.current)
+	return newComparatorSet(comparators...)
+}
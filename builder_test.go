@@ -0,0 +1,89 @@
+package semver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRangeBuilder(t *testing.T) {
+	v := func(input string) *Version {
+		ver, _ := VersionFromString(input)
+		return ver
+	}
+
+	testCases := []struct {
+		title    string
+		builder  *RangeBuilder
+		expected string
+	}{
+		{
+			title:    "bounded",
+			builder:  NewRange().GreaterThanEqual(v("1.2.0")).LessThan(v("2.0.0")),
+			expected: ">=1.2.0 <2.0.0",
+		},
+		{
+			title: "several sets with exclusions",
+			builder: NewRange().
+				GreaterThan(v("1.0.0")).LessThanEqual(v("1.5.0")).NotEqual(v("1.3.1")).
+				Or().
+				Equal(v("2.0.0-rc.1")),
+			expected: ">1.0.0 <=1.5.0 !=1.3.1 || =2.0.0-rc.1",
+		},
+		{
+			title:    "build metadata dropped and partial zero filled",
+			builder:  NewRange().GreaterThanEqual(v("1.2.3+build.4")).LessThan(v("2")),
+			expected: ">=1.2.3 <2.0.0",
+		},
+		{
+			title:    "empty builder",
+			builder:  NewRange(),
+			expected: ">=0.0.0",
+		},
+		{
+			title:    "generic comparator",
+			builder:  NewRange().Comparator(OperatorNotEqual, v("1.0.0")),
+			expected: "!=1.0.0",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			r, err := testCase.builder.Build()
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, r.String())
+
+			parsed, err := RangeFromString(r.String())
+			assert.Nil(t, err)
+			assert.Equal(t, true, parsed.Equivalent(r))
+		})
+	}
+
+	t.Run("built range is evaluated", func(t *testing.T) {
+		r, err := NewRange().GreaterThanEqual(v("2.0.0")).LessThan(v("3.0.0-0")).NotEqual(v("2.3.1")).Build()
+		assert.Nil(t, err)
+		assert.Equal(t, true, v("2.3.0").Satisfies(r))
+		assert.Equal(t, false, v("2.3.1").Satisfies(r))
+		assert.Equal(t, false, v("3.0.0").Satisfies(r))
+	})
+
+	t.Run("options", func(t *testing.T) {
+		r, err := NewRange().LessThan(v("2.0.0")).WithOptions(Options{IncludePrerelease: true}).Build()
+		assert.Nil(t, err)
+		assert.Equal(t, true, v("1.0.0-beta").Satisfies(r))
+
+		r, err = NewRange().GreaterThan(v("2.0.0")).LessThan(v("1.0.0")).
+			WithOptions(Options{RejectUnsatisfiable: true}).Build()
+		assert.Nil(t, r)
+		assert.ErrorIs(t, err, ErrRangeUnsatisfiable)
+	})
+
+	t.Run("invalid comparators", func(t *testing.T) {
+		r, err := NewRange().GreaterThan(nil).Build()
+		assert.Nil(t, r)
+		assert.ErrorIs(t, err, ErrRangeSyntax)
+
+		r, err = NewRange().Comparator(OperatorUnknown, v("1.0.0")).Build()
+		assert.Nil(t, r)
+		assert.ErrorIs(t, err, ErrRangeOperator)
+	})
+}
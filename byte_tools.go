@@ -7,6 +7,7 @@ const (
 	dot       = 0x2e // .
 	plus      = 0x2b // +
 	star      = 0x2a // *
+	bang      = 0x21 // !
 
 	lessThan    = 0x3c // <
 	equal       = 0x3d // =
@@ -99,10 +100,21 @@ func isLowerChar(i byte) bool {
 
 func isOperatorChar(i byte) bool {
 	c := char(i)
-	return c == equal || c == lessThan || c == greaterThan
+	return c == equal || c == lessThan || c == greaterThan || c == bang
 }
 
 func isXRangeChar(i byte) bool {
 	c := char(i)
 	return c == lowerX || c == capitalX || c == star
 }
+
+func compareInts(a int, b int) int {
+	switch {
+	case a > b:
+		return 1
+	case a < b:
+		return -1
+	default:
+		return 0
+	}
+}
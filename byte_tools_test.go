@@ -18,7 +18,7 @@ func Test_isAlphaChar(t *testing.T) {
 }
 
 func Test_isOperatorChar(t *testing.T) {
-	input := "<=<|"
+	input := "<=<|!"
 	res := isOperatorChar(input[0])
 	assert.Equal(t, true, res)
 
@@ -30,4 +30,7 @@ func Test_isOperatorChar(t *testing.T) {
 
 	res = isOperatorChar(input[3])
 	assert.Equal(t, false, res)
+
+	res = isOperatorChar(input[4])
+	assert.Equal(t, true, res)
 }
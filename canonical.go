@@ -0,0 +1,378 @@
+package semver
+
+import (
+	"math"
+	"sort"
+)
+
+// Canonical returns a new range that covers the same versions as the range,
+// in a deterministic normal form. Overlapping and adjacent comparator sets
+// are merged, as are sets that are only separated by pre-releases neither of
+// them allow, and the sets are ordered by their lowest version. For example,
+// `~1.2.0`, `>=1.2.0 <1.3.0-0`, `1.2.x`, and `1.2` all result in
+// `>=1.2.0 <1.3.0-0`.
+//
+// The pre-release rule described by [Version.Satisfies] is preserved. When a
+// merge would lose a set's opt-in to the pre-releases of a tuple, those
+// pre-releases are kept as a set of their own, e.g. `1.0.0-beta || <2.0.0`
+// results in `<2.0.0-0 || =1.0.0-beta`.
+//
+// A range written in a dialect with its own pre-release rule, e.g. the
+// stability flags of Composer, keeps that rule: the sets with the same rule
+// are merged and keep it, and a set with a `!=` that excludes part of a
+// major, e.g. the Masterminds constraint `!=1.2`, is kept as it is.
+func (r *Range) Canonical() *Range {
+	if r.options.IncludePrerelease == true {
+		result := rangeFromIntervals(r.Intervals())
+		result.options = r.options
+		return result
+	}
+	if r.hasPrereleasePolicy() == true {
+		return r.canonicalWithPolicies()
+	}
+
+	intervals := bridgeIntervals(r.Intervals())
+	for i, interval := range intervals {
+		if upper := interval.Upper.Version; upper != nil && upper.pre == "" && interval.Upper.Inclusive == false {
+			// None of the pre-releases of an exclusive upper bound satisfy
+			// the set, so `<1.3.0` is written as `<1.3.0-0`.
+			intervals[i].Upper.Version = newVersion(upper.major, upper.minor, upper.patch, "0")
+		}
+	}
+	result := rangeFromVersions(intervals, r.prereleasesByTuple())
+	result.options = r.options
+	return result
+}
+
+// canonicalWithPolicies is [Range.Canonical] for a range with sets that
+// decide which pre-releases satisfy them with a policy of their own, e.g.
+// the stability flags of Composer. The sets with the same policy are merged,
+// and the results keep that policy. Sets that no pre-release satisfies,
+// e.g. the Masterminds constraint `!=1`, are merged with the sets that
+// follow the rule described by [Version.Satisfies], and are written as they
+// would be without a policy, i.e. `<1.0.0-0 || >=2.0.0`.
+//
+// A set with a `!=` that excludes part of a major, e.g. `!=1.2`, is kept as
+// it is, since its intervals do not reflect the pre-releases it lets
+// through.
+func (r *Range) canonicalWithPolicies() *Range {
+	releases := make([]Interval, 0)
+	plain := make([]ComparatorSet, 0)
+	keys := make([]string, 0)
+	groups := make(map[string][]ComparatorSet)
+	kept := make([]ComparatorSet, 0)
+	for _, set := range r.comparators {
+		switch {
+		case hasPartialSpan(set) == true:
+			kept = append(kept, set)
+		case set.prerelease == nil:
+			releases = append(releases, setIntervals(set)...)
+			plain = append(plain, set)
+		case allowsAnyPrerelease(set) == false:
+			releases = append(releases, setIntervals(set)...)
+		default:
+			key := set.prerelease.String()
+			if _, found := groups[key]; found == false {
+				keys = append(keys, key)
+			}
+			groups[key] = append(groups[key], set)
+		}
+	}
+
+	result := make([]ComparatorSet, 0, len(r.comparators))
+	normalized := bridgeIntervals(normalizeIntervals(releases))
+	prereleases := (&Range{comparators: plain}).prereleasesByTuple()
+	if len(normalized) > 0 || len(prereleases) > 0 {
+		result = append(result, rangeFromVersions(normalized, prereleases).comparators...)
+	}
+	for _, key := range keys {
+		intervals := make([]Interval, 0)
+		for _, set := range groups[key] {
+			intervals = append(intervals, setIntervals(set)...)
+		}
+		for _, interval := range normalizeIntervals(intervals) {
+			set := interval.comparatorSet()
+			set.prerelease = groups[key][0].prerelease
+			result = append(result, set)
+		}
+	}
+	result = append(result, kept...)
+	if len(result) == 0 {
+		return rangeFromIntervals(nil)
+	}
+
+	sort.SliceStable(result, func(a int, b int) bool {
+		return compareLower(lowestBound(result[a]), lowestBound(result[b])) == -1
+	})
+	return &Range{comparators: result, options: r.options}
+}
+
+// lowestBound returns the lower bound of the lowest version covered by the
+// set. A set that does not cover any versions is ordered last.
+func lowestBound(set ComparatorSet) Bound {
+	intervals := setIntervals(set)
+	if len(intervals) == 0 {
+		return Bound{Version: newVersion(math.MaxInt, 0, 0, "")}
+	}
+	return intervals[0].Lower
+}
+
+// hasPartialSpan determines if the set contains a `!=` comparator that
+// excludes part of a major, e.g. `!=1.2`.
+func hasPartialSpan(set ComparatorSet) bool {
+	for _, c := range set.comparators {
+		if c.excludesSpan() == true && c.version.minorParsed == true {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsAnyPrerelease determines if at least one pre-release satisfies the
+// set, when evaluated with its own policy.
+func allowsAnyPrerelease(set ComparatorSet) bool {
+	r := &Range{comparators: []ComparatorSet{set}}
+	for _, label := range prereleaseLabels(r) {
+		for _, interval := range r.satisfyingPrereleases(label) {
+			if containsPrerelease(interval) == true {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// prereleasesByTuple returns the pre-releases that satisfy the range, as
+// intervals within the pre-releases of every tuple the range opts in to.
+func (r *Range) prereleasesByTuple() []Interval {
+	result := make([]Interval, 0)
+	for _, tuple := range prereleaseTuples(r.comparators) {
+		result = append(result, prereleaseIntervals(r.comparators, tuple)...)
+	}
+	return result
+}
+
+// rangeFromVersions builds a range, following the pre-release rule described
+// by [Version.Satisfies], that is satisfied by exactly the releases within
+// the release intervals and the pre-releases within the pre-release
+// intervals. Every pre-release interval must be within the pre-releases of
+// a single tuple, as those of [prereleaseIntervals] are.
+//
+// The bounds of the release intervals are moved off of pre-releases, so
+// they do not opt in to any, e.g. `[1.2.3-beta, 2.0.0-rc)` is written as
+// `>=1.2.3 <2.0.0-0`. A pre-release interval that directly precedes or
+// follows a release interval then becomes its bound, and any other is
+// written as a set of its own.
+func rangeFromVersions(releases []Interval, prereleases []Interval) *Range {
+	intervals := make([]Interval, 0, len(releases)+len(prereleases))
+	for _, interval := range releases {
+		if containsRelease(interval) == false {
+			continue
+		}
+		if lower := interval.Lower.Version; lower == nil && interval.Upper.Version == nil {
+			// Rendered as is, this interval would be `>=0.0.0-0`, which opts
+			// in to the pre-releases of `0.0.0`.
+			interval.Lower = Bound{Version: newVersion(0, 0, 0, ""), Inclusive: true}
+		} else if lower != nil && lower.pre != "" {
+			interval.Lower = Bound{Version: newVersion(lower.major, lower.minor, lower.patch, ""), Inclusive: true}
+		}
+		if upper := interval.Upper.Version; upper != nil && upper.pre != "" &&
+			(upper.pre != "0" || interval.Upper.Inclusive == true) {
+			// Nothing is lower than `1.2.3-0` within its tuple, so it does
+			// not let any pre-releases through.
+			interval.Upper = Bound{Version: newVersion(upper.major, upper.minor, upper.patch, "0")}
+		}
+		intervals = append(intervals, interval)
+	}
+
+	releaseCount := len(intervals)
+	for _, interval := range normalizeIntervals(prereleases) {
+		if interval.Lower.Unbounded() == true {
+			// The set must keep a lower bound in order to opt in to the
+			// pre-releases of `0.0.0`.
+			interval.Lower = Bound{Version: lowestVersion, Inclusive: true}
+		}
+		lower := interval.Lower.Version
+		tuple := newVersion(lower.major, lower.minor, lower.patch, "")
+		merged := false
+		for i := 0; i < releaseCount && merged == false; i++ {
+			release := &intervals[i]
+			if compareUpper(interval.Upper, Bound{Version: tuple}) == 0 &&
+				compareLower(release.Lower, Bound{Version: tuple, Inclusive: true}) == 0 {
+				release.Lower = interval.Lower
+				merged = true
+			} else if compareLower(interval.Lower, Bound{Version: newVersion(tuple.major, tuple.minor, tuple.patch, "0"), Inclusive: true}) == 0 &&
+				(compareUpper(release.Upper, Bound{Version: tuple}) == 0 ||
+					compareUpper(release.Upper, Bound{Version: interval.Lower.Version}) == 0) {
+				release.Upper = interval.Upper
+				merged = true
+			}
+		}
+		if merged == false {
+			intervals = append(intervals, interval)
+		}
+	}
+
+	if len(intervals) == 0 {
+		return rangeFromIntervals(intervals)
+	}
+	sort.SliceStable(intervals, func(a int, b int) bool {
+		lowerResult := compareLower(intervals[a].Lower, intervals[b].Lower)
+		if lowerResult != 0 {
+			return lowerResult == -1
+		}
+		return compareUpper(intervals[a].Upper, intervals[b].Upper) == -1
+	})
+	comparators := make([]ComparatorSet, 0, len(intervals))
+	for _, interval := range intervals {
+		comparators = append(comparators, interval.comparatorSet())
+	}
+	return &Range{comparators: comparators}
+}
+
+// bridgeIntervals merges neighboring intervals when every version in the gap
+// between them is a pre-release. For example, `^1.0.0 || ^2.0.0` only leaves
+// out the pre-releases of `2.0.0` between its two sets, so it is merged into
+// `>=1.0.0 <3.0.0-0`. The pre-releases are accounted for separately by
+// [rangeFromVersions].
+func bridgeIntervals(intervals []Interval) []Interval {
+	result := make([]Interval, 0, len(intervals))
+	for _, interval := range intervals {
+		if len(result) == 0 {
+			result = append(result, interval)
+			continue
+		}
+		last := &result[len(result)-1]
+		gap := Interval{
+			Lower: Bound{Version: last.Upper.Version, Inclusive: !last.Upper.Inclusive},
+			Upper: Bound{Version: interval.Lower.Version, Inclusive: !interval.Lower.Inclusive},
+		}
+		if containsRelease(gap) == true {
+			result = append(result, interval)
+			continue
+		}
+		last.Upper = interval.Upper
+	}
+	return result
+}
+
+// Equivalent determines if the range and the other range are satisfied by
+// exactly the same versions, regardless of how they are written. Each range
+// is evaluated with its own options and pre-release rules, as by
+// [Version.Satisfies].
+func (r *Range) Equivalent(other *Range) bool {
+	return versionsCoveredBy(r, other) && versionsCoveredBy(other, r)
+}
+
+// containsRelease determines if the interval contains at least one version
+// without a pre-release.
+func containsRelease(i Interval) bool {
+	lowest := lowestVersion
+	if i.Lower.Version != nil {
+		lowest = i.Lower.Version
+		if i.Lower.Inclusive == false {
+			lowest = nextVersion(lowest)
+		}
+	}
+	return i.Contains(newVersion(lowest.major, lowest.minor, lowest.patch, ""))
+}
+
+// containsPrerelease determines if the interval contains at least one
+// pre-release version.
+func containsPrerelease(i Interval) bool {
+	lowest := lowestVersion
+	if i.Lower.Version != nil {
+		lowest = i.Lower.Version
+		if i.Lower.Inclusive == false {
+			lowest = nextVersion(lowest)
+		}
+	}
+	if lowest.pre == "" {
+		// The version following a release is a pre-release.
+		lowest = nextVersion(lowest)
+	}
+	return i.Contains(lowest)
+}
+
+// prereleaseTuples returns the `[major, minor, patch]` tuples, as versions,
+// whose pre-releases are opted in to by at least one comparator in the sets.
+// The result is sorted in ascending order.
+func prereleaseTuples(sets []ComparatorSet) []*Version {
+	result := make([]*Version, 0)
+	for _, set := range sets {
+		for _, c := range set.comparators {
+			if c.version.pre == "" || c.operator == OperatorNotEqual {
+				continue
+			}
+			tuple := newVersion(c.version.major, c.version.minor, c.version.patch, "")
+			found := false
+			for _, existing := range result {
+				found = found || existing.Equals(tuple)
+			}
+			if found == false {
+				result = append(result, tuple)
+			}
+		}
+	}
+	sort.SliceStable(result, func(a int, b int) bool {
+		return result[a].Less(result[b]) == true
+	})
+	return result
+}
+
+// prereleaseSpan returns the interval that contains every pre-release of the
+// tuple, i.e. `[1.2.3-0, 1.2.3)` for `1.2.3`.
+func prereleaseSpan(tuple *Version) Interval {
+	return Interval{
+		Lower: Bound{Version: newVersion(tuple.major, tuple.minor, tuple.patch, "0"), Inclusive: true},
+		Upper: Bound{Version: tuple},
+	}
+}
+
+// prereleaseIntervals returns the pre-releases of the tuple that satisfy at
+// least one of the sets.
+func prereleaseIntervals(sets []ComparatorSet, tuple *Version) []Interval {
+	result := make([]Interval, 0)
+	for _, set := range sets {
+		if allowsPrereleaseOfTuple(set, tuple) == false {
+			continue
+		}
+		for _, interval := range setIntervals(tupleSet(set, tuple)) {
+			result = append(result, interval.intersect(prereleaseSpan(tuple)))
+		}
+	}
+	return normalizeIntervals(result)
+}
+
+// tupleSet returns the set with every `!=` comparator that excludes a span,
+// other than that of a whole major, replaced by what it excludes from the
+// pre-releases of the tuple, as described by [inExcludedSpan]. For example,
+// `!=1.2` does not exclude any pre-releases, while `!=1.2-beta` excludes
+// only `1.2.3-beta` from those of `1.2.3`.
+func tupleSet(set ComparatorSet, tuple *Version) ComparatorSet {
+	comparators := make([]*Comparator, 0, len(set.comparators))
+	for _, c := range set.comparators {
+		if c.excludesSpan() == false || c.version.minorParsed == false {
+			comparators = append(comparators, c)
+			continue
+		}
+		if c.version.pre != "" && c.version.major == tuple.major && c.version.minor == tuple.minor {
+			excluded := newVersion(tuple.major, tuple.minor, tuple.patch, c.version.pre)
+			comparators = append(comparators, &Comparator{operator: OperatorNotEqual, version: excluded})
+		}
+	}
+	set.comparators = comparators
+	return set
+}
+
+// allowsPrereleaseOfTuple determines if any comparator in the set opts in to
+// the pre-releases of the tuple.
+func allowsPrereleaseOfTuple(set ComparatorSet, tuple *Version) bool {
+	probe := newVersion(tuple.major, tuple.minor, tuple.patch, "0")
+	for _, c := range set.comparators {
+		if allowsPrerelease(c, probe) == true {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,173 @@
+package semver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRange_Canonical(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{input: "~1.2.0", expected: ">=1.2.0 <1.3.0-0"},
+		{input: ">=1.2.0 <1.3.0-0", expected: ">=1.2.0 <1.3.0-0"},
+		{input: "1.2.x", expected: ">=1.2.0 <1.3.0-0"},
+		{input: "1.2", expected: ">=1.2.0 <1.3.0-0"},
+		{input: "1.2.3", expected: "=1.2.3"},
+		{input: "^2.0.0 !=2.3.1", expected: ">=2.0.0 <2.3.1-0 || >2.3.1 <3.0.0-0"},
+		{input: "*", expected: ">=0.0.0"},
+		{input: "<1.0.0 || >=1.0.0", expected: ">=0.0.0"},
+		{input: ">=2.0.0 <1.0.0", expected: "<0.0.0-0"},
+		{input: "^2.0.0 || ^1.0.0", expected: ">=1.0.0 <3.0.0-0"},
+		{input: "^1.0.0 || 1.5.0 || >=1.8.0 <2.5.0", expected: ">=1.0.0 <2.5.0-0"},
+		{input: ">=1.0.0 <1.5.0 || >1.5.0 <2.0.0", expected: ">=1.0.0 <1.5.0-0 || >1.5.0 <2.0.0-0"},
+		{input: "<=2.0.0 || >1.0.0 <3.0.0 || 3.0.0", expected: "<=3.0.0"},
+
+		// Pre-releases:
+		{input: "1.0.0-beta || <2.0.0", expected: "<2.0.0-0 || =1.0.0-beta"},
+		{input: "^1.2.3-beta.2 || ^1.0.0", expected: ">=1.0.0 <2.0.0-0 || >=1.2.3-beta.2 <1.2.3"},
+		{input: ">=1.0.0-alpha <1.0.0-beta || >1.0.0-rc", expected: ">=1.0.0-alpha <1.0.0-beta || >1.0.0-rc"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			r, err := RangeFromString(testCase.input)
+			assert.Nil(t, err)
+
+			found := r.Canonical()
+			assert.Equal(t, testCase.expected, found.String())
+			assert.Equal(t, true, found.Equivalent(r))
+
+			reparsed, err := RangeFromString(found.String())
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, reparsed.Canonical().String())
+		})
+	}
+}
+
+func TestRange_Equivalent(t *testing.T) {
+	testCases := []struct {
+		a        string
+		b        string
+		expected bool
+	}{
+		{a: "~1.2.0", b: "1.2.x", expected: true},
+		{a: "1.2", b: ">=1.2.0 <1.3.0-0", expected: true},
+		{a: "^1.2.0", b: "~1.2.0", expected: false},
+		{a: "^1.0.0 || ^2.0.0", b: ">=1.0.0 <3.0.0-0", expected: true},
+		{a: "<1.0.0", b: "<1.0.0-0", expected: true},
+		{a: ">1.0.0", b: ">=1.0.1", expected: true},
+		{a: "<=1.0.0", b: "<1.0.1", expected: true},
+		{a: "*", b: "", expected: true},
+		{a: ">=2.0.0 <1.0.0", b: "<0.0.0-0", expected: true},
+		{a: "1.0.0 - 2.0.0", b: ">=1.0.0 <2.0.0", expected: false},
+
+		// Pre-releases:
+		{a: ">1.0.0 <1.0.1", b: "<0.0.0-0", expected: true},
+		{a: "^1.2.3-beta", b: "^1.2.3", expected: false},
+		{a: "^1.2.3-beta", b: "^1.2.3 || >=1.2.3-beta <1.2.3", expected: true},
+		{a: "1.0.0-beta || <2.0.0", b: "<2.0.0", expected: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.a+" and "+testCase.b, func(t *testing.T) {
+			a, err := RangeFromString(testCase.a)
+			assert.Nil(t, err)
+			b, err := RangeFromString(testCase.b)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, a.Equivalent(b))
+			assert.Equal(t, testCase.expected, b.Equivalent(a))
+		})
+	}
+
+	t.Run("include pre-release compares intervals", func(t *testing.T) {
+		opts := Options{IncludePrerelease: true}
+		a, _ := RangeFromStringWithOptions(">1.0.0 <1.0.1", opts)
+		b, _ := RangeFromStringWithOptions("<0.0.0-0", opts)
+		assert.Equal(t, false, a.Equivalent(b))
+
+		a, _ = RangeFromStringWithOptions("~1.2.0", opts)
+		b, _ = RangeFromStringWithOptions(">=1.2.0 <1.3.0-0", opts)
+		assert.Equal(t, true, a.Equivalent(b))
+
+		// `1.2.x` is satisfied by the pre-releases of `1.3.0` once they are
+		// included.
+		b, _ = RangeFromStringWithOptions("1.2.x", opts)
+		assert.Equal(t, false, a.Equivalent(b))
+	})
+
+	t.Run("ranges parsed with different options", func(t *testing.T) {
+		a, _ := RangeFromString("^1.0.0")
+		b, _ := RangeFromStringWithOptions("1.x", Options{IncludePrerelease: true})
+		assert.Equal(t, false, a.Equivalent(b))
+		assert.Equal(t, false, b.Equivalent(a))
+	})
+}
+
+func TestRange_Equivalent_Dialects(t *testing.T) {
+	testCases := []struct {
+		a        string
+		aDialect Dialect
+		b        string
+		bDialect Dialect
+		expected bool
+	}{
+		// `1.0.0-0` is not stable enough for `@beta`.
+		{a: ">=1.0@beta", aDialect: DialectComposer, b: ">=1.0.0-0", bDialect: DialectNPM, expected: false},
+		{a: "^1.2@beta", aDialect: DialectComposer, b: "^1.2@alpha", bDialect: DialectComposer, expected: false},
+		{a: "^1.0.0", aDialect: DialectNPM, b: "[1.0,2.0)", bDialect: DialectMaven, expected: false},
+		{a: "[1.0,2.0)", aDialect: DialectNuGet, b: "[1.0,2.0)", bDialect: DialectMaven, expected: true},
+		{a: "~> 1.2", aDialect: DialectHashiCorp, b: "~> 1.2", bDialect: DialectRubyGems, expected: true},
+		{a: "~> 1.2", aDialect: DialectHashiCorp, b: "~1.2", bDialect: DialectComposer, expected: false},
+	}
+
+	for _, testCase := range testCases {
+		name := testCase.aDialect.String() + " " + testCase.a + " and " + testCase.bDialect.String() + " " + testCase.b
+		t.Run(name, func(t *testing.T) {
+			a, err := ParseRange(testCase.a, testCase.aDialect)
+			assert.Nil(t, err)
+			b, err := ParseRange(testCase.b, testCase.bDialect)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, a.Equivalent(b))
+			assert.Equal(t, testCase.expected, b.Equivalent(a))
+		})
+	}
+}
+
+func TestRange_Canonical_Dialects(t *testing.T) {
+	testCases := []struct {
+		input     string
+		dialect   Dialect
+		expected  string
+		satisfies []string
+		rejects   []string
+	}{
+		{input: "1.0", dialect: DialectMaven, expected: ">=0.0.0-0", satisfies: []string{"0.0.4-0", "1.0.0", "3.0.0-beta"}},
+		{input: "[1.0,2.0),[2.0,3.0)", dialect: DialectMaven, expected: ">=1.0.0 <3.0.0", satisfies: []string{"2.0.0", "2.5.0-beta"}, rejects: []string{"3.0.0"}},
+		{input: "!=1", dialect: DialectMasterminds, expected: "<1.0.0-0 || >=2.0.0", satisfies: []string{"0.9.0", "2.0.0"}, rejects: []string{"1.5.0", "2.0.0-0"}},
+		{input: ">=1.0@beta", dialect: DialectComposer, expected: ">=1.0.0-0", satisfies: []string{"1.0.0-beta", "1.2.0"}, rejects: []string{"1.0.0-0", "1.2.0-alpha"}},
+		{input: "~1.2 || ^1.4@beta", dialect: DialectComposer, expected: ">=1.2.0-0 <2.0.0-0", satisfies: []string{"1.3.0", "1.3.0-beta"}, rejects: []string{"1.3.0-alpha"}},
+		{input: ">=1.2.5-beta, !=1.2", dialect: DialectMasterminds, expected: ">=1.2.5-beta !=1.2"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.dialect.String()+" "+testCase.input, func(t *testing.T) {
+			r, err := ParseRange(testCase.input, testCase.dialect)
+			assert.Nil(t, err)
+			canonical := r.Canonical()
+			assert.Equal(t, testCase.expected, canonical.String())
+			assert.Equal(t, true, r.Equivalent(canonical))
+			for _, input := range testCase.satisfies {
+				v, err := VersionFromString(input)
+				assert.Nil(t, err)
+				assert.Equal(t, true, v.Satisfies(canonical), input)
+			}
+			for _, input := range testCase.rejects {
+				v, err := VersionFromString(input)
+				assert.Nil(t, err)
+				assert.Equal(t, false, v.Satisfies(canonical), input)
+			}
+		})
+	}
+}
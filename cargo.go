@@ -0,0 +1,115 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parseCargoRange parses a Cargo version requirement, e.g. `>=1.2, <1.5`.
+// Cargo differs from npm in a few ways:
+//
+//   - comparators are separated by commas, and there is no `||`
+//   - a bare version is a caret requirement, e.g. `1.2.3` means `^1.2.3`
+//   - a partial version is expanded according to its operator, e.g. `=1.2`
+//     means `>=1.2.0 <1.3.0-0`, `>1.2` means `>=1.3.0`, and `<=1.2` means
+//     `<1.3.0-0`
+//   - wildcards, e.g. `1.*`, may not be combined with an operator other
+//     than `=`
+//
+// See https://doc.rust-lang.org/cargo/reference/specifying-dependencies.html
+func parseCargoRange(input string, opts Options) (*Range, error) {
+	trimmed := strings.TrimSpace(input)
+	if trimmed == "" {
+		return nil, fmt.Errorf("%w: empty cargo version requirement", ErrRangeSyntax)
+	}
+
+	comparators := make([]*Comparator, 0)
+	for _, piece := range strings.Split(trimmed, ",") {
+		piece = strings.TrimSpace(piece)
+		if piece == "" {
+			return nil, fmt.Errorf("%w: empty comparator in `%s`", ErrRangeSyntax, trimmed)
+		}
+		desugared, err := parseCargoComparator(piece)
+		if err != nil {
+			return nil, err
+		}
+		comparators = append(comparators, desugared...)
+	}
+
+	return rangeFromSets([]ComparatorSet{newComparatorSet(comparators...)}, []string{trimmed}, opts)
+}
+
+func parseCargoComparator(input string) ([]*Comparator, error) {
+	rest := strings.TrimLeft(input, "=<>~^!")
+	operator := input[:len(input)-len(rest)]
+	rest = strings.TrimSpace(rest)
+	if strings.ContainsAny(rest, " \t|") == true {
+		return nil, fmt.Errorf("%w: comparators in `%s` must be separated by commas", ErrRangeSyntax, input)
+	}
+
+	v, err := parseTermVersion(rest)
+	if err != nil {
+		return nil, err
+	}
+
+	core, _, _ := strings.Cut(rest, "+")
+	core, _, _ = strings.Cut(core, "-")
+	if strings.ContainsAny(core, "*xX") == true {
+		if operator != "" && operator != "=" {
+			return nil, fmt.Errorf("%w: wildcard `%s` can not be used with `%s`", ErrRangeSyntax, rest, operator)
+		}
+		return cargoWildcard(v), nil
+	}
+
+	switch operator {
+	case "", "^":
+		return desugarCaret(v), nil
+	case "~":
+		return desugarTilde(v), nil
+	case "=":
+		if v.partial == true {
+			return cargoWildcard(v), nil
+		}
+		return []*Comparator{cargoComparator(OperatorEqual, v)}, nil
+	case ">":
+		switch {
+		case v.patchParsed == true:
+			return []*Comparator{cargoComparator(OperatorGreaterThan, v)}, nil
+		case v.minorParsed == true:
+			return []*Comparator{cargoComparator(OperatorGreaterThanEqual, newVersion(v.major, v.minor+1, 0, ""))}, nil
+		default:
+			return []*Comparator{cargoComparator(OperatorGreaterThanEqual, newVersion(v.major+1, 0, 0, ""))}, nil
+		}
+	case ">=":
+		return []*Comparator{cargoComparator(OperatorGreaterThanEqual, v)}, nil
+	case "<":
+		return []*Comparator{cargoComparator(OperatorLessThan, v)}, nil
+	case "<=":
+		switch {
+		case v.patchParsed == true:
+			return []*Comparator{cargoComparator(OperatorLessThanEqual, v)}, nil
+		case v.minorParsed == true:
+			return []*Comparator{cargoComparator(OperatorLessThan, upperBoundVersion(v.major, v.minor+1, 0))}, nil
+		default:
+			return []*Comparator{cargoComparator(OperatorLessThan, upperBoundVersion(v.major+1, 0, 0))}, nil
+		}
+	default:
+		return nil, fmt.Errorf("%w: `%s`", ErrRangeOperator, operator)
+	}
+}
+
+// cargoWildcard expands a wildcard, or a partial exact version, e.g. `1.*`
+// or `=1`, which does not match the pre-releases of its upper bound.
+func cargoWildcard(v *Version) []*Comparator {
+	result := desugarXRange(v)
+	if len(result) == 2 {
+		result[1] = partialUpperBound(v)
+	}
+	return result
+}
+
+// cargoComparator builds a comparator from a zero filled copy of the
+// version, without any build metadata.
+func cargoComparator(operator RangeOperator, v *Version) *Comparator {
+	return &Comparator{operator: operator, version: newVersion(v.major, v.minor, v.patch, v.pre)}
+}
@@ -0,0 +1,103 @@
+package semver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseRange_Cargo(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		// Caret is the default requirement.
+		{input: "1.2.3", expected: ">=1.2.3 <2.0.0-0"},
+		{input: "1.2", expected: ">=1.2.0 <2.0.0-0"},
+		{input: "1", expected: ">=1.0.0 <2.0.0-0"},
+		{input: "0.2.3", expected: ">=0.2.3 <0.3.0-0"},
+		{input: "0.0.3", expected: ">=0.0.3 <0.0.4-0"},
+		{input: "0.0", expected: ">=0.0.0 <0.1.0-0"},
+		{input: "0", expected: ">=0.0.0 <1.0.0-0"},
+		{input: "^1.2.3", expected: ">=1.2.3 <2.0.0-0"},
+		{input: "1.2.3-beta.1", expected: ">=1.2.3-beta.1 <2.0.0-0"},
+
+		// Tilde requirements.
+		{input: "~1.2.3", expected: ">=1.2.3 <1.3.0-0"},
+		{input: "~1.2", expected: ">=1.2.0 <1.3.0-0"},
+		{input: "~1", expected: ">=1.0.0 <2.0.0-0"},
+
+		// Wildcard requirements.
+		{input: "*", expected: ">=0.0.0"},
+		{input: "1.*", expected: ">=1.0.0 <2.0.0-0"},
+		{input: "1.2.*", expected: ">=1.2.0 <1.3.0-0"},
+		{input: "=1.2.x", expected: ">=1.2.0 <1.3.0-0"},
+
+		// Comparison requirements.
+		{input: "=1.2.3", expected: "=1.2.3"},
+		{input: "=1.2", expected: ">=1.2.0 <1.3.0-0"},
+		{input: "=1", expected: ">=1.0.0 <2.0.0-0"},
+		{input: ">1.2.3", expected: ">1.2.3"},
+		{input: ">1.2", expected: ">=1.3.0"},
+		{input: ">1", expected: ">=2.0.0"},
+		{input: ">=1.2", expected: ">=1.2.0"},
+		{input: "<1.2", expected: "<1.2.0"},
+		{input: "<=1.2.3", expected: "<=1.2.3"},
+		{input: "<=1.2", expected: "<1.3.0-0"},
+		{input: "<=1", expected: "<2.0.0-0"},
+
+		// Multiple requirements.
+		{input: ">=1.2, <1.5", expected: ">=1.2.0 <1.5.0"},
+		{input: " >= 1.2.0 ,< 2 ", expected: ">=1.2.0 <2.0.0"},
+		{input: "^1.2, <1.3.0", expected: ">=1.2.0 <2.0.0-0 <1.3.0"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			r, err := ParseRange(testCase.input, DialectCargo)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, r.String())
+		})
+	}
+
+	t.Run("raw keeps the requirement", func(t *testing.T) {
+		r, err := ParseRange(" >=1.2, <1.5 ", DialectCargo)
+		assert.Nil(t, err)
+		assert.Equal(t, ">=1.2, <1.5", r.Raw())
+	})
+
+	t.Run("evaluates like npm", func(t *testing.T) {
+		cargo, _ := ParseRange("1.2.3", DialectCargo)
+		npm, _ := ParseRange("^1.2.3", DialectNPM)
+		assert.Equal(t, true, cargo.Equivalent(npm))
+
+		v, _ := VersionFromString("1.3.0-beta")
+		assert.Equal(t, false, v.Satisfies(cargo))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		testCases := []struct {
+			input    string
+			expected error
+		}{
+			{input: "", expected: ErrRangeSyntax},
+			{input: ">=1.2,", expected: ErrRangeSyntax},
+			{input: ">=1.*", expected: ErrRangeSyntax},
+			{input: "1.2 || 2", expected: ErrRangeSyntax},
+			{input: ">=1.2 <1.5", expected: ErrRangeSyntax},
+			{input: "!=1.2.3", expected: ErrRangeOperator},
+			{input: "~>1.2", expected: ErrRangeOperator},
+			{input: ">=a.b", expected: ErrRangeAlpha},
+		}
+		for _, testCase := range testCases {
+			r, err := ParseRange(testCase.input, DialectCargo)
+			assert.Nil(t, r)
+			assert.ErrorIs(t, err, testCase.expected, testCase.input)
+		}
+	})
+
+	t.Run("unknown dialect", func(t *testing.T) {
+		r, err := ParseRange("1.2.3", Dialect(99))
+		assert.Nil(t, r)
+		assert.ErrorIs(t, err, ErrUnknownDialect)
+	})
+}
@@ -0,0 +1,382 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// composerStability is the stability of a version as understood by
+// Composer, from least to most stable.
+type composerStability int
+
+const (
+	stabilityDev composerStability = iota
+	stabilityAlpha
+	stabilityBeta
+	stabilityRC
+	stabilityStable
+)
+
+func (s composerStability) String() string {
+	switch s {
+	case stabilityDev:
+		return "dev"
+	case stabilityAlpha:
+		return "alpha"
+	case stabilityBeta:
+		return "beta"
+	case stabilityRC:
+		return "RC"
+	default:
+		return "stable"
+	}
+}
+
+// composerStabilityFromFlag parses a stability flag, e.g. the `beta` in
+// `^1.2@beta`.
+func composerStabilityFromFlag(flag string) (composerStability, bool) {
+	switch strings.ToLower(flag) {
+	case "dev":
+		return stabilityDev, true
+	case "alpha":
+		return stabilityAlpha, true
+	case "beta":
+		return stabilityBeta, true
+	case "rc":
+		return stabilityRC, true
+	case "stable":
+		return stabilityStable, true
+	default:
+		return stabilityStable, false
+	}
+}
+
+// composerStabilityOf determines the stability of a pre-release from the
+// letters it starts with, e.g. `beta.2` and `b2` are both beta. A
+// pre-release without a recognized label, e.g. `0`, is as unstable as a
+// development version.
+func composerStabilityOf(pre string) composerStability {
+	if pre == "" {
+		return stabilityStable
+	}
+	label := strings.ToLower(strings.TrimRight(strings.SplitN(pre, ".", 2)[0], "0123456789-"))
+	switch label {
+	case "alpha", "a":
+		return stabilityAlpha
+	case "beta", "b":
+		return stabilityBeta
+	case "rc":
+		return stabilityRC
+	case "stable", "patch", "pl", "p":
+		return stabilityStable
+	default:
+		return stabilityDev
+	}
+}
+
+// composerPolicy is the [prereleasePolicy] of a Composer constraint: a
+// pre-release satisfies the constraint when it is at least as stable as the
+// constraint's minimum stability.
+type composerPolicy struct {
+	minimum composerStability
+}
+
+func (p composerPolicy) String() string {
+	return "@" + p.minimum.String()
+}
+
+func (p composerPolicy) allows(v *Version) bool {
+	return composerStabilityOf(v.pre) >= p.minimum
+}
+
+func (p composerPolicy) lowest(tuple *Version) []*Version {
+	// The lowest pre-release, by precedence, of every stability, in either
+	// letter case since a bound may fall between the two, e.g. `1.2.3-p` is
+	// the lowest pre-release of `>=1.2.3-beta` that is as stable as a
+	// release.
+	labels := []string{"0", "A", "B", "P", "RC", "a", "b", "p", "rc"}
+	result := make([]*Version, 0, len(labels))
+	for _, label := range labels {
+		if composerStabilityOf(label) >= p.minimum {
+			result = append(result, newVersion(tuple.major, tuple.minor, tuple.patch, label))
+		}
+	}
+	return result
+}
+
+var composerVersionPattern = regexp.MustCompile(`^(?i)v?(\d+|[x*])(?:\.(\d+|[x*]))?(?:\.(\d+|[x*]))?(?:\.(\d+|[x*]))?` +
+	`(?:[._-]?((?:stable|beta|b|rc|alpha|a|patch|pl|p)(?:[.-]?\d+)*))?([.-]?dev)?$`)
+
+var composerOrPattern = regexp.MustCompile(`\s*\|\|?\s*`)
+
+var composerHyphenPattern = regexp.MustCompile(`^(\S+)\s+-\s+(\S+)$`)
+
+// parseComposerRange parses a Composer version constraint, e.g.
+// `^7.4 || ~8.0.3`. Composer differs from npm in a few ways:
+//
+//   - comparators are separated by whitespace or commas, and sets by `||`
+//     or `|`
+//   - a bare version is exact, e.g. `1.0` means `=1.0.0`
+//   - `~1.2` means `>=1.2.0 <2.0.0`, while `~1.2.3` means `>=1.2.3 <1.3.0`
+//   - lower bounds include the pre-releases of the bound, e.g. `>=1.2`
+//     means `>=1.2.0-0`
+//   - branch constraints, e.g. `dev-main`, are accepted but no version
+//     satisfies them, since branches are not versions
+//
+// Instead of the pre-release rule described by [Version.Satisfies], a
+// pre-release satisfies the range when it is at least as stable as the
+// minimum stability of the constraint. The minimum stability is the least
+// stable of any stability flag, e.g. `^1.2@beta`, and of any stability
+// named by a version in the constraint, e.g. `>=1.2.0-RC1`. Without either,
+// only stable versions satisfy the range. The stability of a pre-release is
+// determined by its label: `dev`, `alpha` or `a`, `beta` or `b`, and `RC`.
+// Versions are still ordered by semver precedence, so a patch release, e.g.
+// `1.0.0-patch1`, is treated as the release it patches, `1.0.0`.
+//
+// The stability is honored when evaluating versions, e.g. by
+// [Version.Satisfies] and [Range.MinVersion], but functions that work on the
+// intervals of a range, e.g. [Range.Canonical], only consider its
+// comparators.
+//
+// See https://getcomposer.org/doc/articles/versions.md
+func parseComposerRange(input string, opts Options) (*Range, error) {
+	trimmed := strings.TrimSpace(input)
+	if trimmed == "" {
+		return nil, fmt.Errorf("%w: empty composer constraint", ErrRangeSyntax)
+	}
+
+	minimum := stabilityStable
+	sets := make([]ComparatorSet, 0)
+	raw := make([]string, 0)
+	for _, part := range composerOrPattern.Split(trimmed, -1) {
+		if part == "" {
+			return nil, fmt.Errorf("%w: empty set in `%s`", ErrRangeSyntax, trimmed)
+		}
+
+		var comparators []*Comparator
+		var stability composerStability
+		var err error
+		if matches := composerHyphenPattern.FindStringSubmatch(part); matches != nil {
+			comparators, stability, err = parseComposerHyphen(matches[1], matches[2])
+		} else {
+			comparators, stability, err = parseComposerSet(part)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if stability < minimum {
+			minimum = stability
+		}
+
+		sets = append(sets, newComparatorSet(comparators...))
+		raw = append(raw, part)
+	}
+
+	for i := range sets {
+		sets[i].prerelease = composerPolicy{minimum: minimum}
+	}
+	return rangeFromSets(sets, raw, opts)
+}
+
+func parseComposerSet(input string) ([]*Comparator, composerStability, error) {
+	tokens := strings.FieldsFunc(input, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+
+	// Join operators separated from their version by whitespace, e.g.
+	// `>= 1.2`.
+	terms := make([]string, 0, len(tokens))
+	for i := 0; i < len(tokens); i += 1 {
+		term := tokens[i]
+		for strings.Trim(term, "<>=!~^") == "" && i+1 < len(tokens) {
+			i += 1
+			term += tokens[i]
+		}
+		terms = append(terms, term)
+	}
+
+	minimum := stabilityStable
+	comparators := make([]*Comparator, 0, len(terms))
+	for _, term := range terms {
+		desugared, stability, err := parseComposerTerm(term)
+		if err != nil {
+			return nil, minimum, err
+		}
+		if stability < minimum {
+			minimum = stability
+		}
+		comparators = append(comparators, desugared...)
+	}
+	return comparators, minimum, nil
+}
+
+func parseComposerTerm(input string) ([]*Comparator, composerStability, error) {
+	term := input
+	minimum := stabilityStable
+	if at := strings.LastIndex(term, "@"); at >= 0 {
+		flag, ok := composerStabilityFromFlag(term[at+1:])
+		if ok == false {
+			return nil, minimum, fmt.Errorf("%w: unknown stability flag in `%s`", ErrRangeSyntax, input)
+		}
+		minimum = flag
+		term = term[:at]
+		if term == "" {
+			// `@dev` is the same as `*@dev`.
+			term = "*"
+		}
+	}
+
+	if strings.HasPrefix(term, "dev-") == true {
+		// Branches are not versions, so no version satisfies the term.
+		return []*Comparator{{operator: OperatorLessThan, version: lowestVersion}}, stabilityDev, nil
+	}
+
+	rest := strings.TrimLeft(term, "<>=!~^")
+	operator := term[:len(term)-len(rest)]
+	v, wildcard, err := parseComposerVersion(rest)
+	if err != nil {
+		return nil, minimum, err
+	}
+	if stability := composerStabilityOf(v.pre); stability < minimum {
+		minimum = stability
+	}
+
+	if wildcard == true {
+		if operator != "" {
+			return nil, minimum, fmt.Errorf("%w: wildcard `%s` can not be used with `%s`", ErrRangeSyntax, rest, operator)
+		}
+		lower := &Comparator{operator: OperatorGreaterThanEqual, version: newVersion(v.major, v.minor, v.patch, "0")}
+		if v.majorParsed == false {
+			return []*Comparator{{operator: OperatorGreaterThanEqual, version: lowestVersion}}, minimum, nil
+		}
+		return []*Comparator{lower, partialUpperBound(v)}, minimum, nil
+	}
+
+	exact := newVersion(v.major, v.minor, v.patch, v.pre)
+	switch operator {
+	case "", "=", "==":
+		return []*Comparator{{operator: OperatorEqual, version: exact}}, minimum, nil
+	case "!=", "<>":
+		return []*Comparator{{operator: OperatorNotEqual, version: exact}}, minimum, nil
+	case ">":
+		return []*Comparator{{operator: OperatorGreaterThan, version: exact}}, minimum, nil
+	case ">=":
+		return []*Comparator{composerLowerBound(v)}, minimum, nil
+	case "<":
+		return []*Comparator{{operator: OperatorLessThan, version: composerDevVersion(v)}}, minimum, nil
+	case "<=":
+		return []*Comparator{{operator: OperatorLessThanEqual, version: exact}}, minimum, nil
+	case "~":
+		upper := upperBoundVersion(v.major+1, 0, 0)
+		if v.patchParsed == true {
+			// ~1.2.3 => >=1.2.3-0 <1.3.0-0
+			upper = upperBoundVersion(v.major, v.minor+1, 0)
+		}
+		return []*Comparator{composerLowerBound(v), {operator: OperatorLessThan, version: upper}}, minimum, nil
+	case "^":
+		caret := desugarCaret(v)
+		return []*Comparator{composerLowerBound(v), caret[1]}, minimum, nil
+	default:
+		return nil, minimum, fmt.Errorf("%w: `%s`", ErrRangeOperator, operator)
+	}
+}
+
+func parseComposerHyphen(from string, to string) ([]*Comparator, composerStability, error) {
+	lower, _, err := parseComposerVersion(from)
+	if err != nil {
+		return nil, stabilityStable, err
+	}
+	upper, _, err := parseComposerVersion(to)
+	if err != nil {
+		return nil, stabilityStable, err
+	}
+
+	minimum := composerStabilityOf(lower.pre)
+	if stability := composerStabilityOf(upper.pre); stability < minimum {
+		minimum = stability
+	}
+
+	c2 := &Comparator{operator: OperatorLessThanEqual, version: newVersion(upper.major, upper.minor, upper.patch, upper.pre)}
+	if upper.patchParsed == false {
+		c2 = partialUpperBound(upper)
+	}
+	return []*Comparator{composerLowerBound(lower), c2}, minimum, nil
+}
+
+// composerLowerBound builds the inclusive lower bound for the version. A
+// version without a stability includes its own pre-releases, e.g. `>=1.2`
+// means `>=1.2.0-0`.
+func composerLowerBound(v *Version) *Comparator {
+	return &Comparator{operator: OperatorGreaterThanEqual, version: composerDevVersion(v)}
+}
+
+// composerDevVersion returns the lowest pre-release of a version without a
+// stability, matching Composer's `-dev` suffix, e.g. `1.2.0-0` for `1.2`.
+// A patch release is above the pre-releases of its release, so its release
+// is returned instead.
+func composerDevVersion(v *Version) *Version {
+	if v.pre != "" || v.build != "" {
+		return newVersion(v.major, v.minor, v.patch, v.pre)
+	}
+	return newVersion(v.major, v.minor, v.patch, "0")
+}
+
+// parseComposerVersion parses a Composer version, e.g. `v1.2`, `1.0.*`,
+// `1.0.0-beta2` or `2.1.x-dev`. Composer allows a fourth component, which
+// is only accepted when it is zero. A patch release, e.g. `1.0.0-patch1`,
+// `1.0.0-pl1` or `1.0.0-p1`, is stable and is parsed as its release, with
+// the patch as build metadata, e.g. `1.0.0+patch1`. The second returned
+// value indicates the version contains a wildcard.
+func parseComposerVersion(input string) (*Version, bool, error) {
+	matches := composerVersionPattern.FindStringSubmatch(input)
+	if matches == nil {
+		if input != "" && isAlphaChar(input[0]) == true && isXRangeChar(input[0]) == false {
+			return nil, false, fmt.Errorf("%w: `%s`", ErrRangeAlpha, input)
+		}
+		return nil, false, fmt.Errorf("%w: invalid composer version `%s`", ErrRangeSyntax, input)
+	}
+
+	v := &Version{partial: true}
+	wildcard := false
+	components := []*int{&v.major, &v.minor, &v.patch}
+	parsed := []*bool{&v.majorParsed, &v.minorParsed, &v.patchParsed}
+	for i, component := range matches[1:5] {
+		if component == "" || wildcard == true {
+			continue
+		}
+		if strings.ContainsAny(component, "xX*") == true {
+			wildcard = true
+			continue
+		}
+		number, _ := strconv.Atoi(component)
+		if i == 3 {
+			if number != 0 {
+				return nil, false, fmt.Errorf("%w: `%s` has a fourth component that is not zero", ErrRangeSyntax, input)
+			}
+			continue
+		}
+		*components[i] = number
+		*parsed[i] = true
+	}
+	v.partial = v.patchParsed == false
+
+	modifier := strings.TrimLeft(matches[5], ".-")
+	dev := matches[6] != ""
+	switch {
+	case modifier != "" && dev == true:
+		return nil, false, fmt.Errorf("%w: development version of a pre-release `%s` is not supported", ErrRangeSyntax, input)
+	case dev == true:
+		v.pre = "dev"
+	case strings.ToLower(modifier) == "stable":
+	case composerStabilityOf(modifier) == stabilityStable:
+		// A patch release, e.g. `1.0.0-patch1`, is ordered after its release
+		// by Composer, which semver can not express.
+		v.build = modifier
+	default:
+		v.pre = modifier
+	}
+
+	return v, wildcard, nil
+}
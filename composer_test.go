@@ -0,0 +1,139 @@
+package semver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseRange_Composer(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{input: "1.0.2", expected: "=1.0.2"},
+		{input: "1.0", expected: "=1.0.0"},
+		{input: "1.0.0-patch1", expected: "=1.0.0"},
+		{input: "1.0.0-pl1", expected: "=1.0.0"},
+		{input: ">=1.0.0-p1", expected: ">=1.0.0"},
+		{input: "v1.0.0.0", expected: "=1.0.0"},
+		{input: "==1.0.2", expected: "=1.0.2"},
+		{input: ">=1.0", expected: ">=1.0.0-0"},
+		{input: ">=1.0 <2.0", expected: ">=1.0.0-0 <2.0.0-0"},
+		{input: ">=1.0,<2.0", expected: ">=1.0.0-0 <2.0.0-0"},
+		{input: ">= 1.0, < 2.0", expected: ">=1.0.0-0 <2.0.0-0"},
+		{input: ">=1.0 <1.1 || >=1.2", expected: ">=1.0.0-0 <1.1.0-0 || >=1.2.0-0"},
+		{input: ">1.0 | <=0.5", expected: ">1.0.0 || <=0.5.0"},
+		{input: "!=1.5.0", expected: "!=1.5.0"},
+		{input: "<>1.5.0", expected: "!=1.5.0"},
+		{input: "1.0 - 2.0", expected: ">=1.0.0-0 <2.1.0-0"},
+		{input: "1.0.0 - 2.1.0", expected: ">=1.0.0-0 <=2.1.0"},
+		{input: "1.0.*", expected: ">=1.0.0-0 <1.1.0-0"},
+		{input: "1.*", expected: ">=1.0.0-0 <2.0.0-0"},
+		{input: "*", expected: ">=0.0.0-0"},
+		{input: "~1.2", expected: ">=1.2.0-0 <2.0.0-0"},
+		{input: "~1.2.3", expected: ">=1.2.3-0 <1.3.0-0"},
+		{input: "~1", expected: ">=1.0.0-0 <2.0.0-0"},
+		{input: "^1.2.3", expected: ">=1.2.3-0 <2.0.0-0"},
+		{input: "^0.3", expected: ">=0.3.0-0 <0.4.0-0"},
+		{input: "^0.0.3", expected: ">=0.0.3-0 <0.0.4-0"},
+		{input: "^7.4 || ~8.0.3", expected: ">=7.4.0-0 <8.0.0-0 || >=8.0.3-0 <8.1.0-0"},
+		{input: "^1.2@beta", expected: ">=1.2.0-0 <2.0.0-0"},
+		{input: ">=1.0.0-RC1", expected: ">=1.0.0-RC1"},
+		{input: "1.0.0beta2", expected: "=1.0.0-beta2"},
+		{input: "dev-main", expected: "<0.0.0-0"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			r, err := ParseRange(testCase.input, DialectComposer)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, r.String())
+		})
+	}
+
+	t.Run("raw keeps the constraint", func(t *testing.T) {
+		r, err := ParseRange(" ^7.4|~8.0.3@beta ", DialectComposer)
+		assert.Nil(t, err)
+		assert.Equal(t, "^7.4 || ~8.0.3@beta", r.Raw())
+	})
+
+	t.Run("satisfies", func(t *testing.T) {
+		testCases := []struct {
+			constraint string
+			version    string
+			expected   bool
+		}{
+			{constraint: "^1.2", version: "1.9.0", expected: true},
+			{constraint: "^1.2", version: "2.0.0", expected: false},
+			{constraint: "~1.2", version: "1.9.0", expected: true},
+			{constraint: "~1.2.3", version: "1.3.0", expected: false},
+			{constraint: "1.0", version: "1.0.0", expected: true},
+			{constraint: "1.0", version: "1.0.1", expected: false},
+
+			// Stability flags.
+			{constraint: "^1.2", version: "1.3.0-beta.1", expected: false},
+			{constraint: "^1.2@beta", version: "1.3.0-beta.1", expected: true},
+			{constraint: "^1.2@beta", version: "1.3.0-RC1", expected: true},
+			{constraint: "^1.2@beta", version: "1.3.0-alpha.1", expected: false},
+			{constraint: "^1.2@dev", version: "1.3.0-alpha.1", expected: true},
+			{constraint: "^1.2@stable", version: "1.3.0-RC1", expected: false},
+			{constraint: "^1.2@beta", version: "1.2.0-beta.1", expected: true},
+			{constraint: "^1.2 || ^2.0@alpha", version: "1.5.0-alpha.1", expected: true},
+			{constraint: "@dev", version: "0.1.0-dev", expected: true},
+
+			// Stability named by the constraint.
+			{constraint: ">=1.0.0-RC1", version: "1.0.0-RC2", expected: true},
+			{constraint: ">=1.0.0-RC1", version: "1.1.0-RC1", expected: true},
+			{constraint: ">=1.0.0-RC1", version: "1.1.0-beta.1", expected: false},
+			{constraint: "1.0.x-dev", version: "1.0.5-dev", expected: true},
+
+			{constraint: "dev-main", version: "1.0.0", expected: false},
+		}
+		for _, testCase := range testCases {
+			r, err := ParseRange(testCase.constraint, DialectComposer)
+			assert.Nil(t, err)
+			v, _ := VersionFromString(testCase.version)
+			assert.Equal(t, testCase.expected, v.Satisfies(r), testCase.constraint+" "+testCase.version)
+		}
+	})
+
+	t.Run("stability is honored when searching versions", func(t *testing.T) {
+		r, _ := ParseRange(">1.0.0 <=1.0.1@beta", DialectComposer)
+		assert.Equal(t, false, r.IsEmpty())
+		min, err := r.MinVersion()
+		assert.Nil(t, err)
+		assert.Equal(t, "1.0.1-B", min.String())
+
+		r, _ = ParseRange(">1.0.0 <1.0.1@dev", DialectComposer)
+		assert.Equal(t, true, r.IsEmpty())
+	})
+
+	t.Run("explain names the stability flag", func(t *testing.T) {
+		r, _ := ParseRange("^1.2@beta", DialectComposer)
+		v, _ := VersionFromString("1.3.0-beta.1")
+		explanation := r.Explain(v)
+		assert.Equal(t, true, explanation.Satisfied)
+		assert.Equal(t, "@beta", explanation.Sets[0].AllowedBy)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		testCases := []struct {
+			input    string
+			expected error
+		}{
+			{input: "", expected: ErrRangeSyntax},
+			{input: "^1.0 ||", expected: ErrRangeSyntax},
+			{input: ">=1.0.*", expected: ErrRangeSyntax},
+			{input: "^1.0@unstable", expected: ErrRangeSyntax},
+			{input: "1.0.0.1", expected: ErrRangeSyntax},
+			{input: "1.0.0-patch1-dev", expected: ErrRangeSyntax},
+			{input: "=>1.0", expected: ErrRangeOperator},
+			{input: ">=abc", expected: ErrRangeAlpha},
+		}
+		for _, testCase := range testCases {
+			r, err := ParseRange(testCase.input, DialectComposer)
+			assert.Nil(t, r)
+			assert.ErrorIs(t, err, testCase.expected, testCase.input)
+		}
+	})
+}
@@ -0,0 +1,292 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrDebianVersion  = errors.New("invalid Debian version")
+	ErrDebianRelation = errors.New("invalid Debian version relation")
+)
+
+// DebianVersion is a Debian package version, as described by the Debian
+// Policy Manual, e.g. `1:2.30-1ubuntu3.2`. It consists of an optional epoch,
+// the upstream version and an optional Debian revision. Versions are ordered
+// the way `dpkg --compare-versions` orders them, see [DebianVersion.Compare].
+//
+// See https://www.debian.org/doc/debian-policy/ch-controlfields.html#version
+type DebianVersion struct {
+	epoch    int
+	upstream string
+	revision string
+}
+
+// DebianVersionFromString parses a Debian version of the form
+// `[epoch:]upstream[-revision]`. The epoch is the text before the first
+// colon, and the revision is the text after the last hyphen. The upstream
+// version must start with a digit, and may only contain alphanumerics and
+// the characters `.+-~:`, while the revision may only contain alphanumerics
+// and the characters `.+~`. Versions that `dpkg` only warns about, e.g.
+// `a1.0`, are rejected.
+func DebianVersionFromString(input string) (*DebianVersion, error) {
+	trimmed := strings.TrimSpace(input)
+	if trimmed == "" {
+		return nil, fmt.Errorf("%w: empty version", ErrDebianVersion)
+	}
+	if strings.ContainsAny(trimmed, " \t\n") == true {
+		return nil, fmt.Errorf("%w: `%s` contains whitespace", ErrDebianVersion, trimmed)
+	}
+
+	v := &DebianVersion{upstream: trimmed}
+	if epoch, rest, found := strings.Cut(trimmed, ":"); found == true {
+		if epoch == "" {
+			return nil, fmt.Errorf("%w: `%s` has an empty epoch", ErrDebianVersion, trimmed)
+		}
+		for i := 0; i < len(epoch); i += 1 {
+			if isIntegerChar(epoch[i]) == false {
+				return nil, fmt.Errorf("%w: epoch of `%s` is not a number", ErrDebianVersion, trimmed)
+			}
+		}
+		number, err := strconv.Atoi(epoch)
+		if err != nil || number > math.MaxInt32 {
+			return nil, fmt.Errorf("%w: epoch of `%s` is too big", ErrDebianVersion, trimmed)
+		}
+		v.epoch = number
+		v.upstream = rest
+	}
+
+	if index := strings.LastIndex(v.upstream, "-"); index >= 0 {
+		v.revision = v.upstream[index+1:]
+		v.upstream = v.upstream[:index]
+		if v.revision == "" {
+			return nil, fmt.Errorf("%w: `%s` has an empty revision", ErrDebianVersion, trimmed)
+		}
+	}
+
+	if v.upstream == "" {
+		return nil, fmt.Errorf("%w: `%s` has an empty upstream version", ErrDebianVersion, trimmed)
+	}
+	if isIntegerChar(v.upstream[0]) == false {
+		return nil, fmt.Errorf("%w: upstream version of `%s` does not start with a digit", ErrDebianVersion, trimmed)
+	}
+	if invalidDebianChars(v.upstream, ".+-~:") == true {
+		return nil, fmt.Errorf("%w: invalid character in the upstream version of `%s`", ErrDebianVersion, trimmed)
+	}
+	if invalidDebianChars(v.revision, ".+~") == true {
+		return nil, fmt.Errorf("%w: invalid character in the revision of `%s`", ErrDebianVersion, trimmed)
+	}
+
+	return v, nil
+}
+
+// invalidDebianChars determines if the input contains a character that is
+// neither alphanumeric nor one of the allowed characters.
+func invalidDebianChars(input string, allowed string) bool {
+	for i := 0; i < len(input); i += 1 {
+		c := input[i]
+		if isAlphaChar(c) == false && isIntegerChar(c) == false && strings.IndexByte(allowed, c) < 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// String renders the version, e.g. `1:2.30-1ubuntu3.2`. An epoch of zero is
+// omitted.
+func (v *DebianVersion) String() string {
+	builder := strings.Builder{}
+	if v.epoch > 0 {
+		builder.WriteString(strconv.Itoa(v.epoch))
+		builder.WriteString(":")
+	}
+	builder.WriteString(v.upstream)
+	if v.revision != "" {
+		builder.WriteString("-")
+		builder.WriteString(v.revision)
+	}
+	return builder.String()
+}
+
+// Compare evaluates the ordinality between two versions the way
+// `dpkg --compare-versions` does. Results:
+//   - `v > other => 1`
+//   - `v < other => -1`
+//   - `v == other => 0`
+//
+// The epochs are compared first, followed by the upstream versions and the
+// revisions. Those are compared from left to right by alternating runs of
+// non-digits, which are compared character by character, and runs of
+// digits, which are compared numerically. Letters sort before other
+// characters, and `~` sorts before everything, even the end of the version,
+// e.g. `2.30~rc1 < 2.30 < 2.30+git1 < 2.30.1`. A missing revision is the
+// same as a revision of `0`.
+func (v *DebianVersion) Compare(other *DebianVersion) int {
+	if result := compareInts(v.epoch, other.epoch); result != 0 {
+		return result
+	}
+	if result := compareDebianPart(v.upstream, other.upstream); result != 0 {
+		return result
+	}
+	return compareDebianPart(v.revision, other.revision)
+}
+
+// debianOrder is the weight of a character of a non-digit run. The end of a
+// run, or a digit, is weighted as zero.
+func debianOrder(c byte) int {
+	switch {
+	case c == '~':
+		return -1
+	case isIntegerChar(c) == true:
+		return 0
+	case isAlphaChar(c) == true:
+		return int(c)
+	default:
+		return int(c) + 256
+	}
+}
+
+// compareDebianPart compares two upstream versions, or two revisions,
+// following `verrevcmp` of dpkg.
+func compareDebianPart(a string, b string) int {
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for (i < len(a) && isIntegerChar(a[i]) == false) || (j < len(b) && isIntegerChar(b[j]) == false) {
+			ac, bc := 0, 0
+			if i < len(a) {
+				ac = debianOrder(a[i])
+			}
+			if j < len(b) {
+				bc = debianOrder(b[j])
+			}
+			if ac != bc {
+				return compareInts(ac, bc)
+			}
+			i += 1
+			j += 1
+		}
+
+		for i < len(a) && a[i] == '0' {
+			i += 1
+		}
+		for j < len(b) && b[j] == '0' {
+			j += 1
+		}
+		firstDiff := 0
+		for i < len(a) && j < len(b) && isIntegerChar(a[i]) == true && isIntegerChar(b[j]) == true {
+			if firstDiff == 0 {
+				firstDiff = compareInts(int(a[i]), int(b[j]))
+			}
+			i += 1
+			j += 1
+		}
+		if i < len(a) && isIntegerChar(a[i]) == true {
+			return 1
+		}
+		if j < len(b) && isIntegerChar(b[j]) == true {
+			return -1
+		}
+		if firstDiff != 0 {
+			return firstDiff
+		}
+	}
+	return 0
+}
+
+// DebianConstraint is a set of Debian version relations, e.g.
+// `>= 2.30-1, << 2.31`. A version satisfies the set when it satisfies every
+// relation of it.
+type DebianConstraint struct {
+	relations []debianRelation
+}
+
+// debianRelation is a single version relation, e.g. `>= 2.30-1`.
+type debianRelation struct {
+	operator string
+	version  *DebianVersion
+}
+
+var debianRelationPattern = regexp.MustCompile(`^(<<|<=|=|>=|>>)\s*(\S+)$`)
+
+// DebianConstraintFromString parses a comma separated list of Debian version
+// relations, e.g. `>= 1:2.30-1, << 1:2.31`. The supported relations are
+// the ones of a `Depends` field: `<<`, `<=`, `=`, `>=` and `>>`. A relation
+// may be wrapped in parentheses, as in a control file, e.g. `(>= 2.30)`.
+// The obsolete `<` and `>` relations are rejected, since their meaning is
+// ambiguous.
+func DebianConstraintFromString(input string) (*DebianConstraint, error) {
+	trimmed := strings.TrimSpace(input)
+	if trimmed == "" {
+		return nil, fmt.Errorf("%w: empty constraint", ErrDebianRelation)
+	}
+
+	constraint := &DebianConstraint{relations: make([]debianRelation, 0)}
+	for _, piece := range strings.Split(trimmed, ",") {
+		piece = strings.TrimSpace(piece)
+		if strings.HasPrefix(piece, "(") == true && strings.HasSuffix(piece, ")") == true {
+			piece = strings.TrimSpace(piece[1 : len(piece)-1])
+		}
+		if piece == "" {
+			return nil, fmt.Errorf("%w: empty relation in `%s`", ErrDebianRelation, trimmed)
+		}
+
+		matches := debianRelationPattern.FindStringSubmatch(piece)
+		if matches == nil {
+			return nil, fmt.Errorf("%w: `%s`", ErrDebianRelation, piece)
+		}
+		v, err := DebianVersionFromString(matches[2])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrDebianRelation, err)
+		}
+		constraint.relations = append(constraint.relations, debianRelation{operator: matches[1], version: v})
+	}
+	return constraint, nil
+}
+
+// String renders the relations separated by commas, e.g.
+// `>= 2.30-1, << 2.31`.
+func (c *DebianConstraint) String() string {
+	parts := make([]string, 0, len(c.relations))
+	for _, relation := range c.relations {
+		parts = append(parts, relation.operator+" "+relation.version.String())
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Satisfies determines if the version is covered by the provided
+// [DebianConstraint]. Unlike semver, there is not a pre-release rule: a
+// version such as `2.31~rc1` is ordered before `2.31` and satisfies
+// `<< 2.31`.
+func (v *DebianVersion) Satisfies(c *DebianConstraint) bool {
+	for _, relation := range c.relations {
+		result := v.Compare(relation.version)
+		var satisfied bool
+		switch relation.operator {
+		case "<<":
+			satisfied = result < 0
+		case "<=":
+			satisfied = result <= 0
+		case "=":
+			satisfied = result == 0
+		case ">=":
+			satisfied = result >= 0
+		default:
+			satisfied = result > 0
+		}
+		if satisfied == false {
+			return false
+		}
+	}
+	return true
+}
+
+// Check determines if the version satisfies the constraint, as described by
+// [DebianVersion.Satisfies]. It makes a constraint a [Constraint] of Debian
+// versions.
+func (c *DebianConstraint) Check(v *DebianVersion) bool {
+	return v.Satisfies(c)
+}
@@ -0,0 +1,133 @@
+package semver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDebianVersionFromString(t *testing.T) {
+	testCases := []struct {
+		input    string
+		epoch    int
+		upstream string
+		revision string
+		expected string
+	}{
+		{input: "1.0", upstream: "1.0", expected: "1.0"},
+		{input: " 2.30-1 ", upstream: "2.30", revision: "1", expected: "2.30-1"},
+		{input: "1:2.30-1ubuntu3.2", epoch: 1, upstream: "2.30", revision: "1ubuntu3.2", expected: "1:2.30-1ubuntu3.2"},
+		{input: "0:1.0", upstream: "1.0", expected: "1.0"},
+		{input: "2.30~rc1-1", upstream: "2.30~rc1", revision: "1", expected: "2.30~rc1-1"},
+		{input: "0.9+git20261001-2", upstream: "0.9+git20261001", revision: "2", expected: "0.9+git20261001-2"},
+		{input: "1.0-a-b", upstream: "1.0-a", revision: "b", expected: "1.0-a-b"},
+		{input: "1:1.0:2", epoch: 1, upstream: "1.0:2", expected: "1:1.0:2"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			v, err := DebianVersionFromString(testCase.input)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.epoch, v.epoch)
+			assert.Equal(t, testCase.upstream, v.upstream)
+			assert.Equal(t, testCase.revision, v.revision)
+			assert.Equal(t, testCase.expected, v.String())
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		inputs := []string{
+			"", "1.0 1", ":1.0", "1:", "a:1.0", "1.0:2", "99999999999:1", "1.0-", "1:-1", "a1.0", "1.0_2", "1.0-1_2",
+		}
+		for _, input := range inputs {
+			v, err := DebianVersionFromString(input)
+			assert.Nil(t, v)
+			assert.ErrorIs(t, err, ErrDebianVersion, input)
+		}
+	})
+}
+
+func TestDebianVersion_Compare(t *testing.T) {
+	// Every version is lower than the versions after it, as reported by
+	// `dpkg --compare-versions`.
+	ordered := []string{
+		"0.9", "0.9+git20261001-2", "1.0~~", "1.0~~a", "1.0~", "1.0~rc1", "1.0", "1.0-0.1", "1.0-1", "1.0-1ubuntu1",
+		"1.0-1ubuntu3.2", "1.0-2", "1.0-10", "1.0a", "1.0+b1", "1.0.1", "1.00.2", "1.10", "2.30~rc1-1", "2.30-1",
+		"1:0.1", "1:2.30-1ubuntu3.2", "2:0",
+	}
+	for i := 0; i < len(ordered)-1; i += 1 {
+		a, _ := DebianVersionFromString(ordered[i])
+		b, _ := DebianVersionFromString(ordered[i+1])
+		assert.Equal(t, -1, a.Compare(b), ordered[i]+" < "+ordered[i+1])
+		assert.Equal(t, 1, b.Compare(a), ordered[i+1]+" > "+ordered[i])
+	}
+
+	equal := [][]string{
+		{"1.0", "0:1.0"},
+		{"1.0", "1.0-0"},
+		{"1.01", "1.1"},
+		{"1.0-001", "1.0-1"},
+	}
+	for _, pair := range equal {
+		a, _ := DebianVersionFromString(pair[0])
+		b, _ := DebianVersionFromString(pair[1])
+		assert.Equal(t, 0, a.Compare(b), pair[0]+" == "+pair[1])
+	}
+}
+
+func TestDebianConstraintFromString(t *testing.T) {
+	c, err := DebianConstraintFromString(" (>= 0:2.30-1),<<2.31 ")
+	assert.Nil(t, err)
+	assert.Equal(t, ">= 2.30-1, << 2.31", c.String())
+
+	t.Run("errors", func(t *testing.T) {
+		testCases := []struct {
+			input    string
+			expected error
+		}{
+			{input: "", expected: ErrDebianRelation},
+			{input: ">= 1.0,", expected: ErrDebianRelation},
+			{input: "()", expected: ErrDebianRelation},
+			{input: "1.0", expected: ErrDebianRelation},
+			{input: "< 1.0", expected: ErrDebianRelation},
+			{input: "> 1.0", expected: ErrDebianRelation},
+			{input: "== 1.0", expected: ErrDebianRelation},
+			{input: ">= 1.0 << 2.0", expected: ErrDebianRelation},
+			{input: ">= a1.0", expected: ErrDebianVersion},
+		}
+		for _, testCase := range testCases {
+			c, err := DebianConstraintFromString(testCase.input)
+			assert.Nil(t, c)
+			assert.ErrorIs(t, err, testCase.expected, testCase.input)
+		}
+	})
+}
+
+func TestDebianVersion_Satisfies(t *testing.T) {
+	testCases := []struct {
+		constraint string
+		version    string
+		expected   bool
+	}{
+		{constraint: "<< 2.31", version: "2.30-1", expected: true},
+		{constraint: "<< 2.31", version: "2.31~rc1-1", expected: true},
+		{constraint: "<< 2.31", version: "2.31", expected: false},
+		{constraint: "<= 2.31", version: "2.31-0", expected: true},
+		{constraint: "= 1:2.30-1ubuntu3.2", version: "1:2.30-1ubuntu3.2", expected: true},
+		{constraint: "= 1:2.30-1ubuntu3.2", version: "2.30-1ubuntu3.2", expected: false},
+		{constraint: ">= 2.30-1", version: "2.30-1ubuntu1", expected: true},
+		{constraint: ">> 2.30", version: "2.30+git20261001-1", expected: true},
+		{constraint: ">> 2.30", version: "2.30~rc1", expected: false},
+		{constraint: ">= 2.30-1, << 2.31", version: "2.30-5", expected: true},
+		{constraint: ">= 2.30-1, << 2.31", version: "1:2.30-5", expected: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.constraint+" "+testCase.version, func(t *testing.T) {
+			c, err := DebianConstraintFromString(testCase.constraint)
+			assert.Nil(t, err)
+			v, err := DebianVersionFromString(testCase.version)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, v.Satisfies(c))
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package semver
+
+import (
+	"fmt"
+)
+
+// Desugar turns the abstract syntax tree into a [Range] made up solely of
+// primitive comparators, e.g. `^1.2.3` becomes `>=1.2.3 <2.0.0-0`. The
+// resulting range is evaluated according to opts. The nodes of the tree are
+// not altered, so a tree may be desugared any number of times. The
+// [Range.Raw] text of the result is the rendering of the tree in its
+// original syntax.
+func (n *RangeNode) Desugar(opts Options) (*Range, error) {
+	raw := make([]string, 0, len(n.Sets))
+	for _, setNode := range n.Sets {
+		raw = append(raw, setNode.String())
+	}
+	return n.desugar(opts, raw)
+}
+
+// desugar is the same as [RangeNode.Desugar], with the [Range.Raw] text of
+// the result being made up of the provided text for every set.
+func (n *RangeNode) desugar(opts Options, raw []string) (*Range, error) {
+	sets := make([]ComparatorSet, 0, len(n.Sets))
+	for _, setNode := range n.Sets {
+		set, err := setNode.desugar()
+		if err != nil {
+			return nil, err
+		}
+
+		if opts.RejectUnsatisfiable == true {
+			reason := unsatisfiableReason(set, opts)
+			if reason != "" {
+				return nil, fmt.Errorf("%w: comparator set `%s` is empty because %s", ErrRangeUnsatisfiable, setNode, reason)
+			}
+		}
+
+		sets = append(sets, set)
+	}
+
+	if len(sets) == 0 {
+		sets = append(sets, newComparatorSet(anyVersionComparator()))
+	}
+
+	return &Range{comparators: sets, raw: raw, options: opts}, nil
+}
+
+func (n *SetNode) desugar() (ComparatorSet, error) {
+	if len(n.Terms) == 0 {
+		// An empty set matches any version, e.g. the range `""`.
+		return newComparatorSet(anyVersionComparator()), nil
+	}
+
+	comparators := make([]*Comparator, 0, len(n.Terms)*2)
+	for _, term := range n.Terms {
+		desugared, err := desugarTerm(term, len(n.Terms) == 1)
+		if err != nil {
+			return ComparatorSet{}, err
+		}
+		comparators = append(comparators, desugared...)
+	}
+	return newComparatorSet(comparators...), nil
+}
+
+// desugarTerm expands a single term into primitive comparators. The alone
+// parameter indicates that the term is the only term of its set, which
+// alters how a partial version with an operator is expanded.
+func desugarTerm(term TermNode, alone bool) ([]*Comparator, error) {
+	switch t := term.(type) {
+	case *HyphenNode:
+		if t.From == nil || t.To == nil {
+			return nil, fmt.Errorf("%w: hyphen range `%s` is missing a version", ErrRangeSyntax, t)
+		}
+		return desugarHyphen(t.From, t.To), nil
+	case *TildeNode:
+		if t.Version == nil {
+			return nil, fmt.Errorf("%w: tilde range is missing a version", ErrRangeSyntax)
+		}
+		return desugarTilde(t.Version), nil
+	case *CaretNode:
+		if t.Version == nil {
+			return nil, fmt.Errorf("%w: caret range is missing a version", ErrRangeSyntax)
+		}
+		return desugarCaret(t.Version), nil
+	case *XRangeNode:
+		if t.Version == nil {
+			return nil, fmt.Errorf("%w: x-range is missing a version", ErrRangeSyntax)
+		}
+		return desugarXRange(t.Version), nil
+	case *PrimitiveNode:
+		if t.Version == nil {
+			return nil, fmt.Errorf("%w: comparator `%s` is missing a version", ErrRangeSyntax, t.Operator)
+		}
+		if t.Operator.isValid() == false {
+			return nil, fmt.Errorf("%w: `%s`", ErrRangeOperator, t)
+		}
+		return desugarPrimitive(t.Operator, t.Version, alone), nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported term `%s`", ErrRangeSyntax, term)
+	}
+}
+
+func anyVersionComparator() *Comparator {
+	return &Comparator{operator: OperatorGreaterThanEqual, version: newVersion(0, 0, 0, "")}
+}
+
+// copyVersion returns a copy of v so that desugaring never alters the
+// versions held by the nodes of a tree.
+func copyVersion(v *Version) *Version {
+	c := *v
+	return &c
+}
+
+func desugarHyphen(from *Version, to *Version) []*Comparator {
+	c1 := &Comparator{operator: OperatorGreaterThanEqual, version: copyVersion(from)}
+	if to.majorParsed == false {
+		// `1.2.3 - *` has no upper bound.
+		return []*Comparator{c1}
+	}
+
+	c2 := &Comparator{}
+	if to.patchParsed == true {
+		c2.operator = OperatorLessThanEqual
+		c2.version = copyVersion(to)
+	} else if to.minorParsed == true {
+		c2.operator = OperatorLessThan
+		c2.version = newVersion(to.major, to.minor+1, 0, "")
+	} else {
+		c2.operator = OperatorLessThan
+		c2.version = newVersion(to.major+1, 0, 0, "")
+	}
+
+	return []*Comparator{c1, c2}
+}
+
+func desugarTilde(v *Version) []*Comparator {
+	c1 := &Comparator{operator: OperatorGreaterThanEqual, version: copyVersion(v)}
+	if v.majorParsed == false {
+		// `~*` is the same as `*`.
+		return []*Comparator{c1}
+	}
+	// Build metadata does not factor into precedence, so it is of no use
+	// in a range.
+	c1.version.build = ""
+
+	c2 := &Comparator{operator: OperatorLessThan}
+	if v.minorParsed == true {
+		// ~1.2 => >=1.2.0 <1.3.0-0
+		// ~1.2.3 => >=1.2.3 <1.3.0-0
+		// ~1.2.3-beta.2 => >=1.2.3-beta.2 <1.3.0-0
+		c2.version = upperBoundVersion(v.major, v.minor+1, 0)
+	} else {
+		// ~1 => >=1.0.0 <2.0.0-0
+		c2.version = upperBoundVersion(v.major+1, 0, 0)
+	}
+
+	return []*Comparator{c1, c2}
+}
+
+func desugarCaret(v *Version) []*Comparator {
+	c1 := &Comparator{operator: OperatorGreaterThanEqual, version: copyVersion(v)}
+	if v.majorParsed == false {
+		// `^*` is the same as `*`.
+		return []*Comparator{c1}
+	}
+	c1.version.build = ""
+
+	c2 := &Comparator{operator: OperatorLessThan}
+	switch {
+	case v.major > 0 || v.minorParsed == false:
+		// ^1.2.3 => >=1.2.3 <2.0.0-0
+		// ^1.x => >=1.0.0 <2.0.0-0
+		// ^0.x => >=0.0.0 <1.0.0-0
+		c2.version = upperBoundVersion(v.major+1, 0, 0)
+	case v.minor > 0 || v.patchParsed == false:
+		// ^0.2.3 => >=0.2.3 <0.3.0-0
+		// (^0.0.x|^0.0) => >=0.0.0 <0.1.0-0
+		c2.version = upperBoundVersion(v.major, v.minor+1, 0)
+	default:
+		// ^0.0.3 => >=0.0.3 <0.0.4-0
+		// ^0.0.3-beta => >=0.0.3-beta <0.0.4-0
+		c2.version = upperBoundVersion(v.major, v.minor, v.patch+1)
+	}
+
+	return []*Comparator{c1, c2}
+}
+
+func desugarXRange(v *Version) []*Comparator {
+	c1 := &Comparator{operator: OperatorEqual, version: copyVersion(v)}
+	if v.majorParsed == false {
+		// The "any version" x-range, e.g. `*`.
+		c1.operator = OperatorGreaterThanEqual
+		return []*Comparator{c1}
+	}
+	if v.partial == false {
+		return []*Comparator{c1}
+	}
+	c1.operator = OperatorGreaterThanEqual
+	return []*Comparator{c1, buildSecondComparatorFromPartial(c1)}
+}
+
+// desugarPrimitive expands a version prefixed with an operator. A partial
+// version that is the only term of its set retains its operator and gains
+// the upper bound of the x-range, e.g. `>1` => `>1.0.0 <2.0.0`. Within a
+// set of several terms the partial version is zero filled instead, e.g.
+// `>3 <=3.1.0` => `>3.0.0 <=3.1.0`. A partial version excluded with `!=`
+// excludes the span of versions it covers, as described by
+// [inExcludedSpan], e.g. `!=1.2` excludes every `1.2.x` release, and `!=*`
+// excludes every version, i.e. `<0.0.0-0`.
+func desugarPrimitive(op RangeOperator, v *Version, alone bool) []*Comparator {
+	c1 := &Comparator{operator: op, version: copyVersion(v), parsedOperator: true}
+	if op == OperatorNotEqual {
+		if v.majorParsed == false {
+			c1.operator = OperatorLessThan
+			c1.version = copyVersion(lowestVersion)
+		}
+		return []*Comparator{c1}
+	}
+	if v.majorParsed == false {
+		c1.operator = OperatorGreaterThanEqual
+		return []*Comparator{c1}
+	}
+	if v.partial == false || alone == false {
+		return []*Comparator{c1}
+	}
+	return []*Comparator{c1, buildSecondComparatorFromPartial(c1)}
+}
+
+// partialUpperBound builds the exclusive upper bound of the versions matched
+// by a partial version, excluding the pre-releases of the bound, e.g.
+// `<2.0.0-0` for `1.x`. Unlike [buildSecondComparatorFromPartial], which
+// builds the bound of an npm x-range, it is used by the dialects whose
+// wildcards do not match those pre-releases.
+func partialUpperBound(v *Version) *Comparator {
+	if v.minorParsed == true {
+		return &Comparator{operator: OperatorLessThan, version: upperBoundVersion(v.major, v.minor+1, 0)}
+	}
+	return &Comparator{operator: OperatorLessThan, version: upperBoundVersion(v.major+1, 0, 0)}
+}
+
+// upperBoundVersion builds the version used as the exclusive upper bound of
+// a desugared range. The version carries the lowest possible pre-release,
+// `-0`, so that pre-releases of the bound itself are excluded from the
+// range, e.g. `^1.2.3` must not match `2.0.0-beta`.
+func upperBoundVersion(major int, minor int, patch int) *Version {
+	return newVersion(major, minor, patch, "0")
+}
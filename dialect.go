@@ -0,0 +1,105 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrUnknownDialect = errors.New("unknown range dialect")
+
+// Dialect identifies a syntax for writing version ranges. Every dialect is
+// parsed into the same [Range], so ranges written in different dialects can
+// be evaluated and combined with each other.
+type Dialect int
+
+const (
+	// DialectNPM is the syntax of npm's `node-semver`, as parsed by
+	// [RangeFromString].
+	DialectNPM Dialect = iota
+	// DialectCargo is the syntax of Cargo version requirements, e.g.
+	// `>=1.2, <1.5`.
+	DialectCargo
+	// DialectComposer is the syntax of Composer version constraints, e.g.
+	// `^7.4 || ~8.0.3@beta`.
+	DialectComposer
+	// DialectNuGet is the interval notation of NuGet version ranges, e.g.
+	// `[1.0,2.0)`, where a bare version is a minimum.
+	DialectNuGet
+	// DialectMaven is the interval notation of Maven version ranges, e.g.
+	// `[1.0,1.2),[1.3,)`, where a bare version is a soft requirement.
+	DialectMaven
+	// DialectHashiCorp is the syntax of HashiCorp version constraints, as
+	// used by Terraform, e.g. `~> 1.2, != 1.4.0`.
+	DialectHashiCorp
+	// DialectMasterminds is the syntax of Masterminds/semver constraints, as
+	// used by Helm, e.g. `>= 1.2, < 3.0.0 || >= 4.2.3`.
+	DialectMasterminds
+	// DialectRubyGems is the syntax of RubyGems requirements, as used by
+	// Bundler, e.g. `~> 2.3, >= 2.3.1`.
+	DialectRubyGems
+)
+
+func (d Dialect) String() string {
+	switch d {
+	case DialectNPM:
+		return "npm"
+	case DialectCargo:
+		return "cargo"
+	case DialectComposer:
+		return "composer"
+	case DialectNuGet:
+		return "nuget"
+	case DialectMaven:
+		return "maven"
+	case DialectHashiCorp:
+		return "hashicorp"
+	case DialectMasterminds:
+		return "masterminds"
+	case DialectRubyGems:
+		return "rubygems"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseRange parses a range written in the provided dialect.
+func ParseRange(input string, dialect Dialect) (*Range, error) {
+	return ParseRangeWithOptions(input, dialect, Options{})
+}
+
+// ParseRangeWithOptions is the same as [ParseRange] with the parsing, and
+// later evaluation, of the range altered by the provided [Options].
+func ParseRangeWithOptions(input string, dialect Dialect, opts Options) (*Range, error) {
+	switch dialect {
+	case DialectNPM:
+		return RangeFromStringWithOptions(input, opts)
+	case DialectCargo:
+		return parseCargoRange(input, opts)
+	case DialectComposer:
+		return parseComposerRange(input, opts)
+	case DialectNuGet, DialectMaven:
+		return parseIntervalRange(input, dialect, opts)
+	case DialectHashiCorp:
+		return parseHashicorpRange(input, opts)
+	case DialectMasterminds:
+		return parseMastermindsRange(input, opts)
+	case DialectRubyGems:
+		return parseRubygemsRange(input, opts)
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownDialect, dialect)
+	}
+}
+
+// rangeFromSets builds a range out of comparator sets produced by one of the
+// dialect parsers. The raw text of each set is kept for [Range.Raw].
+func rangeFromSets(sets []ComparatorSet, raw []string, opts Options) (*Range, error) {
+	if opts.RejectUnsatisfiable == true {
+		for i, set := range sets {
+			reason := unsatisfiableReason(set, opts)
+			if reason != "" {
+				return nil, fmt.Errorf("%w: comparator set `%s` is empty because %s", ErrRangeUnsatisfiable, raw[i], reason)
+			}
+		}
+	}
+	return &Range{comparators: sets, raw: raw, options: opts}, nil
+}
@@ -0,0 +1,83 @@
+package semver
+
+import (
+	"fmt"
+)
+
+// IsEmpty determines if no version can ever satisfy the range, e.g.
+// `>=2.0.0 <1.0.0` or `<0.0.0-0`.
+func (r *Range) IsEmpty() bool {
+	for _, set := range r.comparators {
+		if lowestWitness(r.options, set) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// unsatisfiableReason explains why the set can never be satisfied when
+// evaluated with the provided options. If the set can be satisfied, an
+// empty string is returned.
+func unsatisfiableReason(set ComparatorSet, opts Options) string {
+	if lowestWitness(opts, set) != nil {
+		return ""
+	}
+
+	var gt, lt, eq *Comparator
+	ne := make([]*Comparator, 0)
+	for _, c := range set.comparators {
+		switch c.operator {
+		case OperatorGreaterThan, OperatorGreaterThanEqual:
+			gt = higherGreaterThan(gt, c)
+		case OperatorLessThan, OperatorLessThanEqual:
+			lt = lowerLessThan(lt, c)
+		case OperatorEqual:
+			if eq != nil && eq.version.Equals(c.version) == false {
+				return fmt.Sprintf("`%s` and `%s` can not both be matched", eq, c)
+			}
+			eq = c
+		case OperatorNotEqual:
+			ne = append(ne, c)
+		default:
+			return fmt.Sprintf("`%s` has an unknown operator", c)
+		}
+	}
+
+	if eq != nil {
+		// An exact version acts as both the lower and upper bound.
+		if gt != nil && inRange(eq.version, gt) == false {
+			return fmt.Sprintf("`%s` is excluded by `%s`", eq, gt)
+		}
+		if lt != nil && inRange(eq.version, lt) == false {
+			return fmt.Sprintf("`%s` is excluded by `%s`", eq, lt)
+		}
+		for _, c := range ne {
+			if inRange(eq.version, c) == false {
+				return fmt.Sprintf("`%s` is excluded by `%s`", eq, c)
+			}
+		}
+	} else if lt != nil && gt == nil && lt.operator == OperatorLessThan && lt.version.Equals(lowestVersion) {
+		return fmt.Sprintf("no version is lower than `%s`", lt.version)
+	} else if gt != nil && lt != nil {
+		switch gt.version.Compare(lt.version) {
+		case 1:
+			return fmt.Sprintf("the lower bound `%s` is above the upper bound `%s`", gt, lt)
+		case 0:
+			return fmt.Sprintf("the bounds `%s` and `%s` exclude each other", gt, lt)
+		}
+	}
+
+	if len(ne) > 0 {
+		bounds := make([]*Comparator, 0, len(set.comparators))
+		for _, c := range set.comparators {
+			if c.operator != OperatorNotEqual {
+				bounds = append(bounds, c)
+			}
+		}
+		if lowestWitness(opts, newComparatorSet(bounds...)) != nil {
+			return "every version it covers is excluded by its `!=` comparators"
+		}
+	}
+
+	return "it only covers pre-release versions that none of its comparators opt in to"
+}
@@ -0,0 +1,123 @@
+package semver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRange_IsEmpty(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected bool
+		opts     Options
+	}{
+		{input: ">=2.0.0 <1.0.0", expected: true},
+		{input: "<0.0.0-0", expected: true},
+		{input: ">1.0.0 <1.0.0", expected: true},
+		{input: "1.0.0 2.0.0", expected: true},
+		{input: "<0.0.0", expected: true},
+		{input: ">1.0.0 <1.0.1", expected: true},
+		{input: ">=2.0.0 <1.0.0 || <0.0.0-0", expected: true},
+
+		{input: ">=1.0.0 <=1.0.0 !=1.0.0", expected: true},
+		{input: ">1.0.0 <1.0.2 !=1.0.1", expected: true},
+		{input: "!=*", expected: true},
+		{input: "1.2.x !=1.2", expected: true},
+
+		{input: "^1.2.3", expected: false},
+		{input: ">1.0.0 <1.0.3 !=1.0.1", expected: false},
+		{input: ">=1.0.0 <=1.0.0", expected: false},
+		{input: "<0.0.0-0 || 1.0.0", expected: false},
+		{input: ">1.0.0 <1.0.1-beta", expected: false},
+		{input: "<0.0.0", expected: false, opts: Options{IncludePrerelease: true}},
+		{input: ">1.0.0 <1.0.1", expected: false, opts: Options{IncludePrerelease: true}},
+		{input: "<0.0.0-0", expected: true, opts: Options{IncludePrerelease: true}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			r, err := RangeFromStringWithOptions(testCase.input, testCase.opts)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, r.IsEmpty())
+		})
+	}
+}
+
+func TestRange_RejectUnsatisfiable(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+		// empty is the result of [Range.IsEmpty] when the range is parsed
+		// without the option.
+		empty bool
+	}{
+		{
+			input:    ">=2.0.0 <1.0.0",
+			expected: "range can not be satisfied by any version: comparator set `>=2.0.0 <1.0.0` is empty because the lower bound `>=2.0.0` is above the upper bound `<1.0.0`",
+			empty:    true,
+		},
+		{
+			input:    "1.0.0 !=1.0.0",
+			expected: "range can not be satisfied by any version: comparator set `1.0.0 !=1.0.0` is empty because `=1.0.0` is excluded by `!=1.0.0`",
+			empty:    true,
+		},
+		{
+			input:    ">1.0.0 <1.0.2 !=1.0.1",
+			expected: "range can not be satisfied by any version: comparator set `>1.0.0 <1.0.2 !=1.0.1` is empty because every version it covers is excluded by its `!=` comparators",
+			empty:    true,
+		},
+		{
+			input:    "^1.0.0 || >1.0.0 <1.0.0",
+			expected: "range can not be satisfied by any version: comparator set `>1.0.0 <1.0.0` is empty because the bounds `>1.0.0` and `<1.0.0` exclude each other",
+		},
+		{
+			input:    "<0.0.0-0",
+			expected: "range can not be satisfied by any version: comparator set `<0.0.0-0` is empty because no version is lower than `0.0.0-0`",
+			empty:    true,
+		},
+		{
+			input:    "!=*",
+			expected: "range can not be satisfied by any version: comparator set `!=*` is empty because no version is lower than `0.0.0-0`",
+			empty:    true,
+		},
+		{
+			input:    "1.0.0 2.0.0",
+			expected: "range can not be satisfied by any version: comparator set `1.0.0 2.0.0` is empty because `=1.0.0` and `=2.0.0` can not both be matched",
+			empty:    true,
+		},
+		{
+			input:    "1.0.0 >1.0.0",
+			expected: "range can not be satisfied by any version: comparator set `1.0.0 >1.0.0` is empty because `=1.0.0` is excluded by `>1.0.0`",
+			empty:    true,
+		},
+		{
+			input:    ">1.0.0 <1.0.1",
+			expected: "range can not be satisfied by any version: comparator set `>1.0.0 <1.0.1` is empty because it only covers pre-release versions that none of its comparators opt in to",
+			empty:    true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			r, err := RangeFromStringWithOptions(testCase.input, Options{RejectUnsatisfiable: true})
+			assert.Nil(t, r)
+			assert.ErrorIs(t, err, ErrRangeUnsatisfiable)
+			assert.Equal(t, testCase.expected, err.Error())
+
+			// The check is opt-in.
+			r, err = RangeFromString(testCase.input)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.empty, r.IsEmpty())
+		})
+	}
+
+	t.Run("satisfiable ranges are accepted", func(t *testing.T) {
+		r, err := RangeFromStringWithOptions("^1.2.3 || >1.0.0 <1.0.1", Options{
+			RejectUnsatisfiable: true,
+			IncludePrerelease:   true,
+		})
+		assert.Nil(t, err)
+		v, _ := VersionFromString("1.0.1-beta")
+		assert.Equal(t, true, v.Satisfies(r))
+	})
+}
@@ -0,0 +1,189 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PrereleaseRule is the outcome of the pre-release rule described by
+// [Version.Satisfies] for a single comparator set.
+type PrereleaseRule int
+
+const (
+	// PrereleaseNotApplicable indicates the version is not a pre-release, so
+	// the rule does not apply.
+	PrereleaseNotApplicable PrereleaseRule = iota
+	// PrereleaseIncluded indicates the rule was disabled by
+	// [Options.IncludePrerelease].
+	PrereleaseIncluded
+	// PrereleaseAllowed indicates a comparator of the set opts in to
+	// pre-releases of the version's `[major, minor, patch]` tuple.
+	PrereleaseAllowed
+	// PrereleaseRejected indicates no comparator of the set opts in to
+	// pre-releases of the version's `[major, minor, patch]` tuple.
+	PrereleaseRejected
+)
+
+func (p PrereleaseRule) String() string {
+	switch p {
+	case PrereleaseNotApplicable:
+		return "not applicable"
+	case PrereleaseIncluded:
+		return "included"
+	case PrereleaseAllowed:
+		return "allowed"
+	case PrereleaseRejected:
+		return "rejected"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText renders the outcome as text, e.g. for use as a JSON value.
+func (p PrereleaseRule) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// Explanation details how a version was evaluated against a range. It is
+// produced by [Range.Explain], and may be rendered as human readable text
+// via [Explanation.String] or marshalled to JSON.
+type Explanation struct {
+	Version   string           `json:"version"`
+	Range     string           `json:"range"`
+	Satisfied bool             `json:"satisfied"`
+	Sets      []SetExplanation `json:"sets"`
+}
+
+// SetExplanation details how a version was evaluated against a single
+// comparator set of a range.
+type SetExplanation struct {
+	// Set is the text of the set as written in the range.
+	Set         string                  `json:"set"`
+	Satisfied   bool                    `json:"satisfied"`
+	Comparators []ComparatorExplanation `json:"comparators"`
+	Prerelease  PrereleaseRule          `json:"prerelease"`
+	// AllowedBy is the comparator that opted in to the pre-release, or the
+	// stability flag that allowed it, if the outcome of the pre-release rule
+	// is [PrereleaseAllowed].
+	AllowedBy string `json:"allowedBy,omitempty"`
+}
+
+// ComparatorExplanation details how a version was evaluated against a single
+// primitive comparator.
+type ComparatorExplanation struct {
+	Comparator string `json:"comparator"`
+	Passed     bool   `json:"passed"`
+}
+
+// Explain evaluates the version against the range, like [Version.Satisfies],
+// while recording the outcome of every comparator and of the pre-release
+// rule for every comparator set.
+func (r *Range) Explain(v *Version) Explanation {
+	explanation := Explanation{
+		Version: v.String(),
+		Range:   r.Raw(),
+		Sets:    make([]SetExplanation, 0, len(r.comparators)),
+	}
+
+	for i, set := range r.comparators {
+		setExplanation := SetExplanation{
+			Set:         set.String(),
+			Satisfied:   true,
+			Comparators: make([]ComparatorExplanation, 0, len(set.comparators)),
+		}
+		if r.raw != nil {
+			setExplanation.Set = r.raw[i]
+		}
+
+		for _, c := range set.comparators {
+			passed := inRange(v, c)
+			setExplanation.Comparators = append(setExplanation.Comparators, ComparatorExplanation{
+				Comparator: c.String(),
+				Passed:     passed,
+			})
+			if passed == false {
+				setExplanation.Satisfied = false
+			}
+		}
+
+		switch {
+		case v.pre == "":
+			setExplanation.Prerelease = PrereleaseNotApplicable
+		case r.options.IncludePrerelease == true:
+			setExplanation.Prerelease = PrereleaseIncluded
+		case set.prerelease != nil:
+			setExplanation.Prerelease = PrereleaseRejected
+			if set.prerelease.allows(v) == true {
+				setExplanation.Prerelease = PrereleaseAllowed
+				setExplanation.AllowedBy = set.prerelease.String()
+			}
+		default:
+			setExplanation.Prerelease = PrereleaseRejected
+			for _, c := range set.comparators {
+				if allowsPrerelease(c, v) == true {
+					setExplanation.Prerelease = PrereleaseAllowed
+					setExplanation.AllowedBy = c.String()
+					break
+				}
+			}
+		}
+		if setExplanation.Prerelease == PrereleaseRejected {
+			setExplanation.Satisfied = false
+		}
+
+		if setExplanation.Satisfied == true {
+			explanation.Satisfied = true
+		}
+		explanation.Sets = append(explanation.Sets, setExplanation)
+	}
+
+	return explanation
+}
+
+// String renders the explanation as human readable text, e.g.:
+//
+//	1.3.0-beta does not satisfy `^1.2.3`
+//	  set `^1.2.3` failed
+//	    >=1.2.3 passed
+//	    <2.0.0-0 passed
+//	    pre-release rule rejected: no comparator opts in to pre-releases of 1.3.0
+func (e Explanation) String() string {
+	builder := strings.Builder{}
+	if e.Satisfied == true {
+		builder.WriteString(fmt.Sprintf("%s satisfies `%s`", e.Version, e.Range))
+	} else {
+		builder.WriteString(fmt.Sprintf("%s does not satisfy `%s`", e.Version, e.Range))
+	}
+
+	for _, set := range e.Sets {
+		builder.WriteString(fmt.Sprintf("\n  set `%s` %s", set.Set, passedString(set.Satisfied)))
+		for _, c := range set.Comparators {
+			builder.WriteString(fmt.Sprintf("\n    %s %s", c.Comparator, passedString(c.Passed)))
+		}
+		switch set.Prerelease {
+		case PrereleaseIncluded:
+			builder.WriteString("\n    pre-release rule disabled: pre-releases are included")
+		case PrereleaseAllowed:
+			builder.WriteString(fmt.Sprintf("\n    pre-release rule passed: %s opts in to pre-releases", set.AllowedBy))
+		case PrereleaseRejected:
+			builder.WriteString(fmt.Sprintf("\n    pre-release rule rejected: no comparator opts in to pre-releases of %s", releaseTuple(e.Version)))
+		}
+	}
+
+	return builder.String()
+}
+
+func passedString(passed bool) string {
+	if passed == true {
+		return "passed"
+	}
+	return "failed"
+}
+
+// releaseTuple trims the pre-release and build metadata from a rendered
+// version, e.g. `1.3.0-beta+build` becomes `1.3.0`.
+func releaseTuple(version string) string {
+	tuple, _, _ := strings.Cut(version, "+")
+	tuple, _, _ = strings.Cut(tuple, "-")
+	return tuple
+}
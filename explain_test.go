@@ -0,0 +1,95 @@
+package semver
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRange_Explain(t *testing.T) {
+	testCases := []struct {
+		title    string
+		rng      string
+		version  string
+		opts     Options
+		expected string
+	}{
+		{
+			title:   "satisfied",
+			rng:     "^1.2.3",
+			version: "1.4.0",
+			expected: "1.4.0 satisfies `^1.2.3`\n" +
+				"  set `^1.2.3` passed\n" +
+				"    >=1.2.3 passed\n" +
+				"    <2.0.0-0 passed",
+		},
+		{
+			title:   "failed comparator in every set",
+			rng:     "~1.2 || 2.x",
+			version: "3.0.0",
+			expected: "3.0.0 does not satisfy `~1.2 || 2.x`\n" +
+				"  set `~1.2` failed\n" +
+				"    >=1.2.0 passed\n" +
+				"    <1.3.0-0 failed\n" +
+				"  set `2.x` failed\n" +
+				"    >=2.0.0 passed\n" +
+				"    <3.0.0 failed",
+		},
+		{
+			title:   "pre-release rejected",
+			rng:     "^1.2.3",
+			version: "1.3.0-beta",
+			expected: "1.3.0-beta does not satisfy `^1.2.3`\n" +
+				"  set `^1.2.3` failed\n" +
+				"    >=1.2.3 passed\n" +
+				"    <2.0.0-0 passed\n" +
+				"    pre-release rule rejected: no comparator opts in to pre-releases of 1.3.0",
+		},
+		{
+			title:   "pre-release allowed",
+			rng:     ">=1.3.0-alpha <2",
+			version: "1.3.0-beta",
+			expected: "1.3.0-beta satisfies `>=1.3.0-alpha <2`\n" +
+				"  set `>=1.3.0-alpha <2` passed\n" +
+				"    >=1.3.0-alpha passed\n" +
+				"    <2.0.0 passed\n" +
+				"    pre-release rule passed: >=1.3.0-alpha opts in to pre-releases",
+		},
+		{
+			title:   "pre-release included",
+			rng:     "^1.2.3",
+			version: "1.3.0-beta",
+			opts:    Options{IncludePrerelease: true},
+			expected: "1.3.0-beta satisfies `^1.2.3`\n" +
+				"  set `^1.2.3` passed\n" +
+				"    >=1.2.3 passed\n" +
+				"    <2.0.0-0 passed\n" +
+				"    pre-release rule disabled: pre-releases are included",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			r, err := RangeFromStringWithOptions(testCase.rng, testCase.opts)
+			assert.Nil(t, err)
+			v, _ := VersionFromString(testCase.version)
+
+			explanation := r.Explain(v)
+			assert.Equal(t, testCase.expected, explanation.String())
+			assert.Equal(t, v.Satisfies(r), explanation.Satisfied)
+		})
+	}
+
+	t.Run("marshals to json", func(t *testing.T) {
+		r, _ := RangeFromString("^1.2.3")
+		v, _ := VersionFromString("1.3.0-beta")
+
+		found, err := json.Marshal(r.Explain(v))
+		assert.Nil(t, err)
+		expected := `{"version":"1.3.0-beta","range":"^1.2.3","satisfied":false,"sets":[` +
+			`{"set":"^1.2.3","satisfied":false,"comparators":[` +
+			`{"comparator":">=1.2.3","passed":true},{"comparator":"<2.0.0-0","passed":true}],` +
+			`"prerelease":"rejected"}]}`
+		assert.JSONEq(t, expected, string(found))
+	})
+}
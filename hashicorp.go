@@ -0,0 +1,130 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// hashicorpPolicy is the [prereleasePolicy] of a HashiCorp constraint: a
+// pre-release only satisfies the constraint when an exact comparator of the
+// constraint names it, e.g. `= 1.2.3-beta`.
+type hashicorpPolicy struct {
+	exact []*Comparator
+}
+
+func (p hashicorpPolicy) String() string {
+	parts := make([]string, 0, len(p.exact))
+	for _, c := range p.exact {
+		parts = append(parts, c.String())
+	}
+	return strings.Join(parts, " ")
+}
+
+func (p hashicorpPolicy) allows(v *Version) bool {
+	for _, c := range p.exact {
+		if inRange(v, c) == true {
+			return true
+		}
+	}
+	return false
+}
+
+func (p hashicorpPolicy) lowest(tuple *Version) []*Version {
+	result := make([]*Version, 0, len(p.exact))
+	for _, c := range p.exact {
+		v := c.version
+		if v.major == tuple.major && v.minor == tuple.minor && v.patch == tuple.patch {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// parseHashicorpRange parses a HashiCorp version constraint, as used by
+// Terraform, e.g. `>= 1.2.0, < 2.0.0`. HashiCorp differs from npm in a few
+// ways:
+//
+//   - comparators are separated by commas, and there is no `||`
+//   - a bare version is exact, and partial versions are zero filled, e.g.
+//     `1.2` means `=1.2.0` and `> 1.2` means `>1.2.0`
+//   - `~>` allows only the rightmost component of the version to increase,
+//     e.g. `~> 1.2` means `>=1.2.0 <2.0.0-0`, while `~> 1.2.3` means
+//     `>=1.2.3 <1.3.0-0`; `~> 1` has no upper bound
+//
+// Instead of the pre-release rule described by [Version.Satisfies], a
+// pre-release only satisfies the constraint when it is named exactly by an
+// `=` comparator of the constraint, e.g. `>= 1.2.0-beta` is not satisfied by
+// any pre-release, while `= 1.2.0-beta` is satisfied by `1.2.0-beta`.
+//
+// See https://developer.hashicorp.com/terraform/language/expressions/version-constraints
+func parseHashicorpRange(input string, opts Options) (*Range, error) {
+	trimmed := strings.TrimSpace(input)
+	if trimmed == "" {
+		return nil, fmt.Errorf("%w: empty hashicorp version constraint", ErrRangeSyntax)
+	}
+
+	comparators := make([]*Comparator, 0)
+	exact := make([]*Comparator, 0)
+	for _, piece := range strings.Split(trimmed, ",") {
+		piece = strings.TrimSpace(piece)
+		if piece == "" {
+			return nil, fmt.Errorf("%w: empty comparator in `%s`", ErrRangeSyntax, trimmed)
+		}
+		desugared, err := parseHashicorpComparator(piece)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range desugared {
+			if c.operator == OperatorEqual && c.version.pre != "" {
+				exact = append(exact, c)
+			}
+		}
+		comparators = append(comparators, desugared...)
+	}
+
+	set := newComparatorSet(comparators...)
+	set.prerelease = hashicorpPolicy{exact: exact}
+	return rangeFromSets([]ComparatorSet{set}, []string{trimmed}, opts)
+}
+
+func parseHashicorpComparator(input string) ([]*Comparator, error) {
+	rest := strings.TrimLeft(input, "=<>~!")
+	operator := input[:len(input)-len(rest)]
+	rest = strings.TrimSpace(rest)
+	if strings.ContainsAny(rest, " \t|") == true {
+		return nil, fmt.Errorf("%w: comparators in `%s` must be separated by commas", ErrRangeSyntax, input)
+	}
+
+	v, err := parseLooseVersion(rest)
+	if err != nil {
+		return nil, err
+	}
+	exact := newVersion(v.major, v.minor, v.patch, v.pre)
+
+	switch operator {
+	case "", "=":
+		return []*Comparator{{operator: OperatorEqual, version: exact}}, nil
+	case "!=":
+		return []*Comparator{{operator: OperatorNotEqual, version: exact}}, nil
+	case ">":
+		return []*Comparator{{operator: OperatorGreaterThan, version: exact}}, nil
+	case ">=":
+		return []*Comparator{{operator: OperatorGreaterThanEqual, version: exact}}, nil
+	case "<":
+		return []*Comparator{{operator: OperatorLessThan, version: exact}}, nil
+	case "<=":
+		return []*Comparator{{operator: OperatorLessThanEqual, version: exact}}, nil
+	case "~>":
+		lower := &Comparator{operator: OperatorGreaterThanEqual, version: exact}
+		switch {
+		case v.patchParsed == true:
+			return []*Comparator{lower, {operator: OperatorLessThan, version: upperBoundVersion(v.major, v.minor+1, 0)}}, nil
+		case v.minorParsed == true:
+			return []*Comparator{lower, {operator: OperatorLessThan, version: upperBoundVersion(v.major+1, 0, 0)}}, nil
+		default:
+			return []*Comparator{lower}, nil
+		}
+	default:
+		return nil, fmt.Errorf("%w: `%s`", ErrRangeOperator, operator)
+	}
+}
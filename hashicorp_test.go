@@ -0,0 +1,114 @@
+package semver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseRange_HashiCorp(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		// Exact versions.
+		{input: "1.2.3", expected: "=1.2.3"},
+		{input: "= 1.2", expected: "=1.2.0"},
+		{input: "v1.2.3-beta", expected: "=1.2.3-beta"},
+		{input: "1.2.3.0", expected: "=1.2.3"},
+
+		// Comparison operators.
+		{input: "> 1.2", expected: ">1.2.0"},
+		{input: ">= 1.2.3", expected: ">=1.2.3"},
+		{input: "< 2", expected: "<2.0.0"},
+		{input: "<= 1.2.3", expected: "<=1.2.3"},
+		{input: "!= 1.4", expected: "!=1.4.0"},
+
+		// Pessimistic operator.
+		{input: "~> 1.2", expected: ">=1.2.0 <2.0.0-0"},
+		{input: "~> 1.2.3", expected: ">=1.2.3 <1.3.0-0"},
+		{input: "~>1.2.3-beta", expected: ">=1.2.3-beta <1.3.0-0"},
+		{input: "~> 1", expected: ">=1.0.0"},
+
+		// Multiple constraints.
+		{input: ">= 1.2.0, < 2.0.0", expected: ">=1.2.0 <2.0.0"},
+		{input: "~> 1.2, != 1.4.0", expected: ">=1.2.0 <2.0.0-0 !=1.4.0"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			r, err := ParseRange(testCase.input, DialectHashiCorp)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, r.String())
+		})
+	}
+
+	t.Run("raw keeps the constraint", func(t *testing.T) {
+		r, err := ParseRange(" ~> 1.2, != 1.4.0 ", DialectHashiCorp)
+		assert.Nil(t, err)
+		assert.Equal(t, "~> 1.2, != 1.4.0", r.Raw())
+	})
+
+	t.Run("satisfies", func(t *testing.T) {
+		testCases := []struct {
+			input    string
+			version  string
+			expected bool
+		}{
+			{input: "~> 1.2", version: "1.9.9", expected: true},
+			{input: "~> 1.2", version: "2.0.0", expected: false},
+			{input: "~> 1.2.3", version: "1.2.9", expected: true},
+			{input: "~> 1.2.3", version: "1.3.0", expected: false},
+			{input: "~> 1", version: "5.0.0", expected: true},
+			{input: "~> 1.2, != 1.4.0", version: "1.4.0", expected: false},
+
+			// Pre-releases must be named exactly.
+			{input: "= 1.2.3-beta", version: "1.2.3-beta", expected: true},
+			{input: "1.2.3-beta", version: "1.2.3-beta+build", expected: true},
+			{input: ">= 1.2.3-beta", version: "1.2.3-rc", expected: false},
+			{input: ">= 1.2.3-beta", version: "1.2.3", expected: true},
+			{input: "~> 1.2.3-beta", version: "1.2.3-rc", expected: false},
+			{input: ">= 1.0.0, = 1.2.3-beta", version: "1.2.3-beta", expected: true},
+			{input: ">= 1.0.0", version: "1.2.3-beta", expected: false},
+		}
+		for _, testCase := range testCases {
+			r, err := ParseRange(testCase.input, DialectHashiCorp)
+			assert.Nil(t, err)
+			v, _ := VersionFromString(testCase.version)
+			assert.Equal(t, testCase.expected, v.Satisfies(r), testCase.input+" "+testCase.version)
+		}
+	})
+
+	t.Run("min version of an exact pre-release", func(t *testing.T) {
+		r, _ := ParseRange("= 1.2.3-beta", DialectHashiCorp)
+		min, err := r.MinVersion()
+		assert.Nil(t, err)
+		assert.Equal(t, "1.2.3-beta", min.String())
+
+		explanation := r.Explain(min)
+		assert.Equal(t, PrereleaseAllowed, explanation.Sets[0].Prerelease)
+		assert.Equal(t, "=1.2.3-beta", explanation.Sets[0].AllowedBy)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		testCases := []struct {
+			input    string
+			expected error
+		}{
+			{input: "", expected: ErrRangeSyntax},
+			{input: ">= 1.2,", expected: ErrRangeSyntax},
+			{input: ">= 1.2 < 2.0", expected: ErrRangeSyntax},
+			{input: "1.2 || 2.0", expected: ErrRangeSyntax},
+			{input: "1.x", expected: ErrRangeSyntax},
+			{input: "1.2.3.4", expected: ErrRangeSyntax},
+			{input: "^1.2", expected: ErrRangeSyntax},
+			{input: "=> 1.2", expected: ErrRangeOperator},
+			{input: "~ 1.2", expected: ErrRangeOperator},
+			{input: ">= a.b", expected: ErrRangeAlpha},
+		}
+		for _, testCase := range testCases {
+			r, err := ParseRange(testCase.input, DialectHashiCorp)
+			assert.Nil(t, r)
+			assert.ErrorIs(t, err, testCase.expected, testCase.input)
+		}
+	})
+}
@@ -0,0 +1,172 @@
+package semver
+
+// Intersects determines if there is at least one version that could satisfy
+// both ranges. That is, at least one comparator set in the range, and at least
+// one comparator set in the other range, can be satisfied by the same version.
+//
+// This answers the same question as the `intersects` function of npm's
+// `node-semver`, but the pre-release rule described by [Version.Satisfies]
+// is fully accounted for. For example, `>=1.3.0-beta` and `<1.3.0` do not
+// intersect because the second range never matches a pre-release of `1.3.0`,
+// unless it was parsed with [Options.IncludePrerelease]. Each range is
+// evaluated with its own options, as by [Version.Satisfies].
+func (r *Range) Intersects(other *Range) bool {
+	for _, set := range r.evaluatedSets() {
+		for _, otherSet := range other.evaluatedSets() {
+			if lowestWitness(Options{}, set, otherSet) != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Intersects determines if there is at least one version that could satisfy
+// both comparators, e.g. `>=1.0.0` and `<2.0.0` intersect while `<1.0.0` and
+// `>2.0.0` do not. Each comparator is evaluated as if it were a range on
+// its own.
+func (c *Comparator) Intersects(other *Comparator) bool {
+	return lowestWitness(Options{}, newComparatorSet(c), newComparatorSet(other)) != nil
+}
+
+// includePrereleasePolicy is the [prereleasePolicy] of a set of a range
+// parsed with [Options.IncludePrerelease]: every pre-release within the
+// bounds of the set satisfies it.
+type includePrereleasePolicy struct{}
+
+func (p includePrereleasePolicy) String() string {
+	return "IncludePrerelease"
+}
+
+func (p includePrereleasePolicy) allows(v *Version) bool {
+	return true
+}
+
+func (p includePrereleasePolicy) lowest(tuple *Version) []*Version {
+	return []*Version{newVersion(tuple.major, tuple.minor, tuple.patch, "0")}
+}
+
+// evaluatedSets returns the comparator sets of the range, with the options
+// of the range applied to them. This allows sets of ranges parsed with
+// different options to be evaluated together with [lowestWitness].
+func (r *Range) evaluatedSets() []ComparatorSet {
+	if r.options.IncludePrerelease == false {
+		return r.comparators
+	}
+	result := make([]ComparatorSet, 0, len(r.comparators))
+	for _, set := range r.comparators {
+		set.prerelease = includePrereleasePolicy{}
+		result = append(result, set)
+	}
+	return result
+}
+
+// lowestWitness finds the lowest version that satisfies every one of the
+// provided sets when evaluated with the provided options. If no such version
+// exists, `nil` is returned.
+//
+// The comparators of all sets combined describe a single interval of versions.
+// Within that interval, a version can only satisfy the sets if it is either a
+// release, or a pre-release of a tuple that every set opts in to. So the
+// lowest satisfying version must be one of: the lowest version in the
+// interval, the lowest release in the interval, or the lowest pre-release in
+// the interval for each tuple the sets opt in to. A version excluded by a `!=`
+// comparator is skipped over, so the versions directly following every
+// excluded version are candidates as well, along with the versions directly
+// following the lowest version and the end of a span excluded by a `!=` with
+// a partial version. A set with a pre-release policy
+// contributes the lowest pre-releases that policy could allow.
+func lowestWitness(opts Options, sets ...ComparatorSet) *Version {
+	lowest := newVersion(0, 0, 0, "0")
+	for _, set := range sets {
+		for _, c := range set.comparators {
+			var candidate *Version
+			switch c.operator {
+			case OperatorEqual, OperatorGreaterThanEqual:
+				candidate = c.version
+			case OperatorGreaterThan:
+				candidate = nextVersion(c.version)
+			default:
+				continue
+			}
+			if candidate.Greater(lowest) == true {
+				lowest = candidate
+			}
+		}
+	}
+
+	candidates := []*Version{
+		lowest,
+		newVersion(lowest.major, lowest.minor, lowest.patch, ""),
+	}
+	for _, c := range sets[0].comparators {
+		if c.version.pre == "" || c.operator == OperatorNotEqual {
+			continue
+		}
+		tupleStart := newVersion(c.version.major, c.version.minor, c.version.patch, "0")
+		if tupleStart.Greater(lowest) == true {
+			candidates = append(candidates, tupleStart)
+		}
+	}
+
+	for _, set := range sets {
+		for _, c := range set.comparators {
+			if c.operator != OperatorNotEqual {
+				continue
+			}
+			next := nextVersion(c.version)
+			candidates = append(candidates, next, newVersion(next.major, next.minor, next.patch, ""))
+			if c.excludesSpan() == true {
+				end := upperBoundVersion(c.version.major, c.version.minor+1, 0)
+				if c.version.minorParsed == false {
+					end = upperBoundVersion(c.version.major+1, 0, 0)
+				}
+				next = nextVersion(lowest)
+				candidates = append(candidates, next, end, newVersion(end.major, end.minor, end.patch, ""))
+			}
+		}
+	}
+
+	for _, set := range sets {
+		if set.prerelease == nil {
+			continue
+		}
+		tuples := []*Version{lowest}
+		for _, c := range set.comparators {
+			tuples = append(tuples, c.version, nextVersion(c.version))
+		}
+		for _, tuple := range tuples {
+			tuple = newVersion(tuple.major, tuple.minor, tuple.patch, "")
+			candidates = append(candidates, set.prerelease.lowest(tuple)...)
+		}
+	}
+
+	var result *Version
+	for _, candidate := range candidates {
+		if result != nil && candidate.GreaterThanEquals(result) == true {
+			continue
+		}
+		satisfied := true
+		for _, set := range sets {
+			if setSatisfiedBy(set, candidate, opts) == false {
+				satisfied = false
+				break
+			}
+		}
+		if satisfied == true {
+			result = candidate
+		}
+	}
+	return result
+}
+
+// nextVersion returns the version with the lowest precedence that is still
+// higher than the provided version. For example, the next version after
+// `1.2.3` is `1.2.4-0`, and the next version after `1.2.3-beta` is
+// `1.2.3-beta.0`.
+func nextVersion(v *Version) *Version {
+	if v.pre != "" {
+		return newVersion(v.major, v.minor, v.patch, v.pre+".0")
+	}
+	return newVersion(v.major, v.minor, v.patch+1, "0")
+}
@@ -0,0 +1,129 @@
+package semver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestComparator_Intersects(t *testing.T) {
+	testCases := []struct {
+		a        string
+		b        string
+		expected bool
+	}{
+		{a: "1.3.0", b: ">=1.3.0", expected: true},
+		{a: "1.3.0", b: ">1.3.0", expected: false},
+		{a: ">=1.3.0", b: "1.3.0", expected: true},
+		{a: ">1.3.0", b: "1.3.0", expected: false},
+		{a: ">=1.3.0", b: "<1.3.0", expected: false},
+		{a: "<1.3.0", b: ">=1.3.0", expected: false},
+		{a: "<=1.3.0", b: ">=1.3.0", expected: true},
+		{a: "<1.3.0", b: "<=1.3.0", expected: true},
+		{a: ">1.3.0", b: ">=2.0.0", expected: true},
+		{a: ">1.0.0", b: "<2.0.0", expected: true},
+		{a: "<1.0.0", b: ">2.0.0", expected: false},
+		{a: ">2.0.0", b: "<1.0.0", expected: false},
+		{a: "<0.0.0-0", b: "<1.0.0", expected: false},
+		{a: ">=1.0.0", b: "<0.0.0", expected: false},
+
+		// Pre-release tuples:
+		{a: "1.3.0-beta", b: ">1.3.0-alpha", expected: true},
+		{a: "1.3.0-alpha", b: ">1.3.0-beta", expected: false},
+		{a: "1.3.0-beta", b: ">1.0.0", expected: false},
+		{a: ">=1.3.0-beta", b: "<1.3.0", expected: false},
+		{a: ">=1.3.0-beta", b: "<1.3.0-rc", expected: true},
+		{a: ">1.3.0-beta", b: "<1.3.0-alpha", expected: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.a+" with "+testCase.b, func(t *testing.T) {
+			a, err := parseComparator([]byte(testCase.a))
+			assert.Nil(t, err)
+			b, err := parseComparator([]byte(testCase.b))
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, a.Intersects(b))
+			assert.Equal(t, testCase.expected, b.Intersects(a))
+		})
+	}
+}
+
+func TestRange_Intersects(t *testing.T) {
+	testCases := []struct {
+		a        string
+		b        string
+		expected bool
+		aOpts    Options
+	}{
+		{a: "1.3.0 || <1.0.0 >2.0.0", b: "1.3.0 || <1.0.0 >2.0.0", expected: true},
+		{a: "<1.0.0 >2.0.0", b: ">0.0.0", expected: false},
+		{a: "<1.0.0 >2.0.0", b: ">1.4.0 <1.6.0 || 2.0.0", expected: false},
+		{a: ">1.0.0 <=2.0.0", b: "2.0.0", expected: true},
+		{a: "<1.0.0 >=2.0.0", b: "2.1.0", expected: false},
+		{a: "1.5.x", b: "<1.5.0", expected: false},
+		{a: "^1.0.0 !=1.5.0", b: "1.5.0", expected: false},
+		{a: "^1.0.0 !=1.5.0", b: ">=1.5.0 <=1.5.1", expected: true},
+		{a: "1.0.0 - 2.0.0", b: "2.0.0 - 3.0.0", expected: true},
+		{a: "1.0.0 - 2.0.0", b: "2.0.1 - 3.0.0", expected: false},
+		{a: "^1.2", b: ">=2.0.0 <3", expected: false},
+		{a: "^1.2", b: ">=1.9.0 <3", expected: true},
+		{a: "^1.2 || ^3", b: ">=2.0.0 <4", expected: true},
+		{a: "~0.2.3", b: "^0.3.0", expected: false},
+		{a: "*", b: "<0.0.0-0", expected: false},
+		{a: "", b: "1.2.3", expected: true},
+
+		// Pre-release tuples:
+		{a: "^1.2.3-beta.2", b: "1.2.3-beta.4", expected: true},
+		{a: "^1.2.3-beta.2", b: "1.2.3-beta.1", expected: false},
+		{a: "^1.2.3-beta.2", b: "1.2.4-beta.4", expected: false},
+		{a: "^1.2.3", b: "<2.0.0-0", expected: true},
+		{a: ">=1.5.0-beta <1.5.0", b: ">1.0.0 <2.0.0", expected: false},
+		{a: ">=1.5.0-beta <1.5.0", b: ">1.0.0 <1.5.0-rc", expected: true},
+		{a: ">=1.5.0-beta <1.5.0", b: ">1.5.0-alpha <1.5.0-rc", expected: true},
+
+		// Ranges parsed with different options:
+		{a: ">=1.5.0-beta <1.5.0", b: ">1.0.0 <2.0.0", expected: false, aOpts: Options{IncludePrerelease: true}},
+		{a: ">1.0.0 <2.0.0", b: ">=1.5.0-beta <1.5.0", expected: true, aOpts: Options{IncludePrerelease: true}},
+		{a: "<1.5.0", b: ">=1.5.0-beta <1.5.0", expected: false},
+		{a: "<1.5.0", b: ">=1.5.0-beta <1.5.0", expected: true, aOpts: Options{IncludePrerelease: true}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.a+" with "+testCase.b, func(t *testing.T) {
+			a, err := RangeFromStringWithOptions(testCase.a, testCase.aOpts)
+			assert.Nil(t, err)
+			b, err := RangeFromString(testCase.b)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, a.Intersects(b))
+			assert.Equal(t, testCase.expected, b.Intersects(a))
+		})
+	}
+}
+
+func TestRange_Intersects_Dialects(t *testing.T) {
+	testCases := []struct {
+		a        string
+		aDialect Dialect
+		b        string
+		bDialect Dialect
+		expected bool
+	}{
+		// `1.2.0-p` is as stable as a release to Composer.
+		{a: ">=1.2.0-beta <1.2.0", aDialect: DialectNPM, b: "~1.2", bDialect: DialectComposer, expected: true},
+		{a: ">=1.2.0-beta <1.2.0-p", aDialect: DialectNPM, b: "~1.2", bDialect: DialectComposer, expected: false},
+		{a: ">=1.2.0-beta <1.2.0-p", aDialect: DialectNPM, b: "~1.2@beta", bDialect: DialectComposer, expected: true},
+		{a: ">= 1.0.0-beta", aDialect: DialectHashiCorp, b: "1.0.0-beta1", bDialect: DialectComposer, expected: false},
+		{a: "= 1.0.0-beta1", aDialect: DialectHashiCorp, b: "1.0.0-beta1", bDialect: DialectComposer, expected: true},
+	}
+
+	for _, testCase := range testCases {
+		name := testCase.aDialect.String() + " " + testCase.a + " with " + testCase.bDialect.String() + " " + testCase.b
+		t.Run(name, func(t *testing.T) {
+			a, err := ParseRange(testCase.a, testCase.aDialect)
+			assert.Nil(t, err)
+			b, err := ParseRange(testCase.b, testCase.bDialect)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, a.Intersects(b))
+			assert.Equal(t, testCase.expected, b.Intersects(a))
+		})
+	}
+}
@@ -0,0 +1,421 @@
+package semver
+
+import (
+	"sort"
+	"strings"
+)
+
+// Bound is one end of an [Interval].
+type Bound struct {
+	// Version is the version at the end of the interval. It is `nil` when
+	// the interval is unbounded in this direction.
+	Version *Version
+	// Inclusive indicates that Version itself is part of the interval.
+	Inclusive bool
+}
+
+// Unbounded indicates the interval is open-ended in this direction, e.g. the
+// upper bound of `>=1.0.0`.
+func (b Bound) Unbounded() bool {
+	return b.Version == nil
+}
+
+// Interval is a contiguous span of versions, ordered by precedence. A lower
+// bound of `>=0.0.0-0` is reported as unbounded, since there is not any
+// lower version.
+type Interval struct {
+	Lower Bound
+	Upper Bound
+}
+
+// Contains determines if the version is within the interval.
+func (i Interval) Contains(v *Version) bool {
+	point := Interval{
+		Lower: Bound{Version: v, Inclusive: true},
+		Upper: Bound{Version: v, Inclusive: true},
+	}
+	return i.intersect(point).isEmpty() == false
+}
+
+// String renders the interval in mathematical notation, e.g.
+// `[1.2.3, 2.0.0-0)`. An unbounded end is rendered as an empty endpoint,
+// e.g. `(, 1.0.0)`.
+func (i Interval) String() string {
+	builder := strings.Builder{}
+	if i.Lower.Inclusive == true {
+		builder.WriteString("[")
+	} else {
+		builder.WriteString("(")
+	}
+	if i.Lower.Version != nil {
+		builder.WriteString(i.Lower.Version.String())
+	}
+	builder.WriteString(", ")
+	if i.Upper.Version != nil {
+		builder.WriteString(i.Upper.Version.String())
+	}
+	if i.Upper.Inclusive == true {
+		builder.WriteString("]")
+	} else {
+		builder.WriteString(")")
+	}
+	return builder.String()
+}
+
+// lowestVersion is the version with the lowest possible precedence.
+var lowestVersion = newVersion(0, 0, 0, "0")
+
+// isEmpty determines if the interval does not contain any versions.
+func (i Interval) isEmpty() bool {
+	if i.Upper.Version == nil {
+		return false
+	}
+	if i.Lower.Version == nil {
+		// Nothing is lower than `0.0.0-0`.
+		return i.Upper.Inclusive == false && i.Upper.Version.Equals(lowestVersion)
+	}
+	switch i.Lower.Version.Compare(i.Upper.Version) {
+	case 1:
+		return true
+	case 0:
+		return i.Lower.Inclusive == false || i.Upper.Inclusive == false
+	default:
+		return false
+	}
+}
+
+// single returns the only version within the interval, e.g. `1.2.3` for
+// `[1.2.3, 1.2.3]`. When the interval may contain more versions, or none,
+// `nil` is returned.
+func (i Interval) single() *Version {
+	if i.Lower.Version == nil || i.Upper.Version == nil || i.Lower.Inclusive == false || i.Upper.Inclusive == false {
+		return nil
+	}
+	if i.Lower.Version.Equals(i.Upper.Version) == false {
+		return nil
+	}
+	return i.Lower.Version
+}
+
+// intersect returns the versions contained in both intervals. The result
+// may be empty.
+func (i Interval) intersect(other Interval) Interval {
+	result := i
+	if compareLower(other.Lower, result.Lower) == 1 {
+		result.Lower = other.Lower
+	}
+	if compareUpper(other.Upper, result.Upper) == -1 {
+		result.Upper = other.Upper
+	}
+	return result
+}
+
+// comparatorSet converts the interval into the equivalent set of primitive
+// comparators.
+func (i Interval) comparatorSet() ComparatorSet {
+	if i.Lower.Version == nil && i.Upper.Version == nil {
+		return newComparatorSet(&Comparator{operator: OperatorGreaterThanEqual, version: lowestVersion})
+	}
+	if i.Lower.Version == nil {
+		return newComparatorSet(upperComparator(i.Upper))
+	}
+	if i.Upper.Version == nil {
+		return newComparatorSet(lowerComparator(i.Lower))
+	}
+	if i.Lower.Inclusive == true && i.Upper.Inclusive == true && i.Lower.Version.Equals(i.Upper.Version) {
+		return newComparatorSet(&Comparator{operator: OperatorEqual, version: i.Lower.Version})
+	}
+	return newComparatorSet(lowerComparator(i.Lower), upperComparator(i.Upper))
+}
+
+func lowerComparator(b Bound) *Comparator {
+	if b.Inclusive == true {
+		return &Comparator{operator: OperatorGreaterThanEqual, version: b.Version}
+	}
+	return &Comparator{operator: OperatorGreaterThan, version: b.Version}
+}
+
+func upperComparator(b Bound) *Comparator {
+	if b.Inclusive == true {
+		return &Comparator{operator: OperatorLessThanEqual, version: b.Version}
+	}
+	return &Comparator{operator: OperatorLessThan, version: b.Version}
+}
+
+// compareLower evaluates the ordinality between two lower bounds. An
+// unbounded lower bound is lower than any other, and an inclusive bound is
+// lower than an exclusive bound on the same version.
+func compareLower(a Bound, b Bound) int {
+	switch {
+	case a.Version == nil && b.Version == nil:
+		return 0
+	case a.Version == nil:
+		return -1
+	case b.Version == nil:
+		return 1
+	}
+	result := a.Version.Compare(b.Version)
+	if result != 0 || a.Inclusive == b.Inclusive {
+		return result
+	}
+	if a.Inclusive == true {
+		return -1
+	}
+	return 1
+}
+
+// compareUpper evaluates the ordinality between two upper bounds. An
+// unbounded upper bound is higher than any other, and an inclusive bound is
+// higher than an exclusive bound on the same version.
+func compareUpper(a Bound, b Bound) int {
+	switch {
+	case a.Version == nil && b.Version == nil:
+		return 0
+	case a.Version == nil:
+		return 1
+	case b.Version == nil:
+		return -1
+	}
+	result := a.Version.Compare(b.Version)
+	if result != 0 || a.Inclusive == b.Inclusive {
+		return result
+	}
+	if a.Inclusive == true {
+		return 1
+	}
+	return -1
+}
+
+// setIntervals determines the intervals covered by a comparator set. The
+// pre-release rule described by [Version.Satisfies] is not considered; the
+// set is treated as if it were evaluated with [Options.IncludePrerelease].
+// Every `!=` comparator splits the interval around the excluded version.
+func setIntervals(set ComparatorSet) []Interval {
+	return labeledSetIntervals(set, "")
+}
+
+// labeledSetIntervals is the same as [setIntervals], for the versions with
+// the pre-release label. A `!=` with a partial version only excludes the
+// versions of its span with the same pre-release, as described by
+// [inExcludedSpan]. For example, `!=1.2-beta` is only reflected for the
+// label `beta`, and `!=1.2` only for releases, i.e. the empty label.
+func labeledSetIntervals(set ComparatorSet, pre string) []Interval {
+	result := Interval{}
+	excluded := make([]*Version, 0)
+	spans := make([]Interval, 0)
+	for _, c := range set.comparators {
+		lower := Interval{Lower: Bound{Version: c.version}}
+		upper := Interval{Upper: Bound{Version: c.version}}
+		switch c.operator {
+		case OperatorGreaterThan:
+			result = result.intersect(lower)
+		case OperatorGreaterThanEqual:
+			lower.Lower.Inclusive = true
+			result = result.intersect(lower)
+		case OperatorLessThan:
+			result = result.intersect(upper)
+		case OperatorLessThanEqual:
+			upper.Upper.Inclusive = true
+			result = result.intersect(upper)
+		case OperatorEqual:
+			result = result.intersect(Interval{
+				Lower: Bound{Version: c.version, Inclusive: true},
+				Upper: Bound{Version: c.version, Inclusive: true},
+			})
+		case OperatorNotEqual:
+			if c.excludesSpan() == true {
+				if c.version.minorParsed == false || comparePre(pre, c.version.pre) == 0 {
+					spans = append(spans, excludedSpan(c.version)...)
+				}
+				continue
+			}
+			excluded = append(excluded, c.version)
+		default:
+			// An unknown operator never matches a version.
+			return []Interval{}
+		}
+	}
+
+	intervals := []Interval{result}
+	for _, v := range excluded {
+		split := make([]Interval, 0, len(intervals)+1)
+		for _, i := range intervals {
+			if i.Contains(v) == false {
+				split = append(split, i)
+				continue
+			}
+			split = append(split,
+				Interval{Lower: i.Lower, Upper: Bound{Version: v}},
+				Interval{Lower: Bound{Version: v}, Upper: i.Upper},
+			)
+		}
+		intervals = split
+	}
+	if len(spans) > 0 {
+		intervals = intersectIntervals(normalizeIntervals(intervals), complementIntervals(normalizeIntervals(spans)))
+	}
+	return normalizeIntervals(intervals)
+}
+
+// excludedSpan returns the interval excluded by a `!=` with the partial
+// version, as described by [inExcludedSpan]. A missing minor component
+// excludes `[1.0.0-0, 2.0.0-0)` for `!=1`. A missing patch component
+// excludes the versions of the span with the same pre-release, which are
+// all within `[1.2.0, 1.3.0-0)` for `!=1.2`, and `[1.2.0-beta, 1.3.0-0)`
+// for `!=1.2-beta`. The other versions within that interval still satisfy
+// the comparator, which is left to [labeledSetIntervals].
+func excludedSpan(partial *Version) []Interval {
+	if partial.minorParsed == false {
+		return []Interval{{
+			Lower: Bound{Version: newVersion(partial.major, 0, 0, "0"), Inclusive: true},
+			Upper: Bound{Version: upperBoundVersion(partial.major+1, 0, 0)},
+		}}
+	}
+	return []Interval{{
+		Lower: Bound{Version: newVersion(partial.major, partial.minor, 0, partial.pre), Inclusive: true},
+		Upper: Bound{Version: upperBoundVersion(partial.major, partial.minor+1, 0)},
+	}}
+}
+
+// normalizeIntervals sorts the intervals, drops any that are empty, and
+// merges any that overlap or are adjacent. The result is a list of disjoint
+// intervals in ascending order.
+func normalizeIntervals(intervals []Interval) []Interval {
+	sorted := make([]Interval, 0, len(intervals))
+	for _, i := range intervals {
+		if i.isEmpty() == true {
+			continue
+		}
+		if i.Lower.Inclusive == true && i.Lower.Version.Equals(lowestVersion) == true {
+			// Nothing is lower than `0.0.0-0`, so the interval is unbounded.
+			i.Lower = Bound{}
+		}
+		sorted = append(sorted, i)
+	}
+	sort.SliceStable(sorted, func(a int, b int) bool {
+		return compareLower(sorted[a].Lower, sorted[b].Lower) == -1
+	})
+
+	result := make([]Interval, 0, len(sorted))
+	for _, i := range sorted {
+		if len(result) == 0 {
+			result = append(result, i)
+			continue
+		}
+		last := &result[len(result)-1]
+		if touches(*last, i) == false {
+			result = append(result, i)
+			continue
+		}
+		if compareUpper(i.Upper, last.Upper) == 1 {
+			last.Upper = i.Upper
+		}
+	}
+	return result
+}
+
+// touches determines if the interval `b`, which must not start before `a`,
+// overlaps or is adjacent to `a` such that the two could be merged into
+// a single interval.
+func touches(a Interval, b Interval) bool {
+	if a.Upper.Version == nil || b.Lower.Version == nil {
+		return true
+	}
+	switch b.Lower.Version.Compare(a.Upper.Version) {
+	case -1:
+		return true
+	case 0:
+		return a.Upper.Inclusive == true || b.Lower.Inclusive == true
+	default:
+		return false
+	}
+}
+
+// complementIntervals returns the intervals not covered by the provided
+// normalized intervals.
+func complementIntervals(intervals []Interval) []Interval {
+	result := make([]Interval, 0, len(intervals)+1)
+	lower := Bound{}
+	for _, i := range intervals {
+		if i.Lower.Version != nil {
+			gap := Interval{
+				Lower: lower,
+				Upper: Bound{Version: i.Lower.Version, Inclusive: !i.Lower.Inclusive},
+			}
+			if gap.isEmpty() == false {
+				result = append(result, gap)
+			}
+		}
+		if i.Upper.Version == nil {
+			return result
+		}
+		lower = Bound{Version: i.Upper.Version, Inclusive: !i.Upper.Inclusive}
+	}
+	return append(result, Interval{Lower: lower})
+}
+
+// intersectIntervals returns the intervals covered by both lists of
+// normalized intervals.
+func intersectIntervals(a []Interval, b []Interval) []Interval {
+	result := make([]Interval, 0)
+	for _, i := range a {
+		for _, j := range b {
+			result = append(result, i.intersect(j))
+		}
+	}
+	return normalizeIntervals(result)
+}
+
+// Intervals returns the versions covered by the range as a list of disjoint
+// intervals in ascending order. Overlapping and adjacent comparator sets are
+// merged, and sets that can never be satisfied are dropped, so a range that
+// does not cover any versions results in an empty list.
+//
+// The pre-release rule described by [Version.Satisfies] is not reflected in
+// the intervals. For example, the intervals for `^1.2.3` are
+// `[1.2.3, 2.0.0-0)` even though `1.5.0-beta` does not satisfy the range.
+// Nor are the pre-releases let through by a partial `!=`, e.g. `!=1.2`
+// excludes `[1.2.0, 1.3.0-0)` even though `1.2.5-beta` may satisfy it.
+func (r *Range) Intervals() []Interval {
+	result := make([]Interval, 0, len(r.comparators))
+	for _, set := range r.comparators {
+		result = append(result, setIntervals(set)...)
+	}
+	return normalizeIntervals(result)
+}
+
+// LowerBound returns the lowest end of the versions covered by the range.
+// The second returned value is `false` when the range does not cover any
+// versions.
+func (r *Range) LowerBound() (Bound, bool) {
+	intervals := r.Intervals()
+	if len(intervals) == 0 {
+		return Bound{}, false
+	}
+	return intervals[0].Lower, true
+}
+
+// UpperBound returns the highest end of the versions covered by the range.
+// The second returned value is `false` when the range does not cover any
+// versions.
+func (r *Range) UpperBound() (Bound, bool) {
+	intervals := r.Intervals()
+	if len(intervals) == 0 {
+		return Bound{}, false
+	}
+	return intervals[len(intervals)-1].Upper, true
+}
+
+// rangeFromIntervals builds a range from a list of normalized intervals. A
+// range that does not contain any versions is represented as `<0.0.0-0`.
+func rangeFromIntervals(intervals []Interval) *Range {
+	if len(intervals) == 0 {
+		empty := newComparatorSet(&Comparator{operator: OperatorLessThan, version: lowestVersion})
+		return &Range{comparators: []ComparatorSet{empty}}
+	}
+	comparators := make([]ComparatorSet, 0, len(intervals))
+	for _, i := range intervals {
+		comparators = append(comparators, i.comparatorSet())
+	}
+	return &Range{comparators: comparators}
+}
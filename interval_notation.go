@@ -0,0 +1,257 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// intervalPolicy is the [prereleasePolicy] of a range written in interval
+// notation: every pre-release within the bounds of the range satisfies it,
+// as there is not a pre-release rule in NuGet or Maven.
+type intervalPolicy struct{}
+
+func (p intervalPolicy) String() string {
+	return "interval notation"
+}
+
+func (p intervalPolicy) allows(v *Version) bool {
+	return true
+}
+
+func (p intervalPolicy) lowest(tuple *Version) []*Version {
+	return []*Version{newVersion(tuple.major, tuple.minor, tuple.patch, "0")}
+}
+
+var looseVersionPattern = regexp.MustCompile(`^v?(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:\.(\d+))?` +
+	`(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?(?:\+[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?$`)
+
+// parseIntervalRange parses a range written in the interval notation of
+// NuGet and Maven, e.g. `[1.0,2.0)` or `(,1.0],[1.2,)`. Intervals are
+// separated by commas, and a version satisfies the range when it is within
+// any of them:
+//
+//   - `[` and `]` include the bound, while `(` and `)` exclude it
+//   - an empty bound is unbounded, e.g. `(,1.5]` means `<=1.5.0`
+//   - a single version in brackets is exact, e.g. `[1.2.3]` means `=1.2.3`
+//   - a bare version is a minimum for NuGet, e.g. `1.0` means `>=1.0.0`,
+//     while for Maven it is a soft requirement that any version satisfies
+//
+// Partial versions are zero filled, and a fourth component is accepted when
+// it is zero. There is not a pre-release rule: every pre-release within the
+// bounds of an interval satisfies it, e.g. `[1.0,2.0)` is satisfied by
+// `2.0.0-beta`. Versions are still ordered by semver precedence.
+//
+// See https://learn.microsoft.com/en-us/nuget/concepts/package-versioning#version-ranges
+// and https://maven.apache.org/pom.html#dependency-version-requirement-specification
+func parseIntervalRange(input string, dialect Dialect, opts Options) (*Range, error) {
+	trimmed := strings.TrimSpace(input)
+	if trimmed == "" {
+		return nil, fmt.Errorf("%w: empty %s version range", ErrRangeSyntax, dialect)
+	}
+
+	if trimmed[0] != '[' && trimmed[0] != '(' {
+		v, err := parseIntervalVersion(trimmed)
+		if err != nil {
+			return nil, err
+		}
+		comparator := &Comparator{operator: OperatorGreaterThanEqual, version: v}
+		if dialect == DialectMaven {
+			comparator = &Comparator{operator: OperatorGreaterThanEqual, version: lowestVersion}
+		}
+		set := newComparatorSet(comparator)
+		set.prerelease = intervalPolicy{}
+		return rangeFromSets([]ComparatorSet{set}, []string{trimmed}, opts)
+	}
+
+	sets := make([]ComparatorSet, 0)
+	raw := make([]string, 0)
+	rest := trimmed
+	for rest != "" {
+		if rest[0] != '[' && rest[0] != '(' {
+			return nil, fmt.Errorf("%w: expected `[` or `(` at `%s`", ErrRangeSyntax, rest)
+		}
+		end := strings.IndexAny(rest, "])")
+		if end < 0 {
+			return nil, fmt.Errorf("%w: unterminated interval `%s`", ErrRangeSyntax, rest)
+		}
+
+		set, err := parseIntervalSet(rest[:end+1])
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, set)
+		raw = append(raw, rest[:end+1])
+
+		rest = strings.TrimSpace(rest[end+1:])
+		if rest == "" {
+			break
+		}
+		if rest[0] != ',' {
+			return nil, fmt.Errorf("%w: intervals must be separated by commas in `%s`", ErrRangeSyntax, trimmed)
+		}
+		rest = strings.TrimSpace(rest[1:])
+		if rest == "" {
+			return nil, fmt.Errorf("%w: trailing comma in `%s`", ErrRangeSyntax, trimmed)
+		}
+	}
+
+	rng, err := rangeFromSets(sets, raw, opts)
+	if err != nil {
+		return nil, err
+	}
+	rng.source = strings.Join(raw, ",")
+	return rng, nil
+}
+
+// parseIntervalSet parses a single interval, including its brackets, e.g.
+// `[1.0,2.0)`.
+func parseIntervalSet(input string) (ComparatorSet, error) {
+	lowerInclusive := input[0] == '['
+	upperInclusive := input[len(input)-1] == ']'
+	bounds := strings.Split(input[1:len(input)-1], ",")
+
+	var comparators []*Comparator
+	switch len(bounds) {
+	case 1:
+		if lowerInclusive == false || upperInclusive == false {
+			return ComparatorSet{}, fmt.Errorf("%w: exact version `%s` must be in square brackets", ErrRangeSyntax, input)
+		}
+		v, err := parseIntervalVersion(strings.TrimSpace(bounds[0]))
+		if err != nil {
+			return ComparatorSet{}, err
+		}
+		comparators = []*Comparator{{operator: OperatorEqual, version: v}}
+	case 2:
+		lower, upper := strings.TrimSpace(bounds[0]), strings.TrimSpace(bounds[1])
+		comparators = make([]*Comparator, 0, 2)
+		var lowerVersion *Version
+		if lower != "" {
+			v, err := parseIntervalVersion(lower)
+			if err != nil {
+				return ComparatorSet{}, err
+			}
+			operator := OperatorGreaterThan
+			if lowerInclusive == true {
+				operator = OperatorGreaterThanEqual
+			}
+			comparators = append(comparators, &Comparator{operator: operator, version: v})
+			lowerVersion = v
+		}
+		if upper != "" {
+			v, err := parseIntervalVersion(upper)
+			if err != nil {
+				return ComparatorSet{}, err
+			}
+			if lowerVersion != nil && lowerVersion.Greater(v) == true {
+				return ComparatorSet{}, fmt.Errorf("%w: lower bound of `%s` is greater than its upper bound", ErrRangeSyntax, input)
+			}
+			operator := OperatorLessThan
+			if upperInclusive == true {
+				operator = OperatorLessThanEqual
+			}
+			comparators = append(comparators, &Comparator{operator: operator, version: v})
+		}
+		if len(comparators) == 0 {
+			comparators = append(comparators, &Comparator{operator: OperatorGreaterThanEqual, version: lowestVersion})
+		}
+	default:
+		return ComparatorSet{}, fmt.Errorf("%w: interval `%s` has more than two bounds", ErrRangeSyntax, input)
+	}
+
+	set := newComparatorSet(comparators...)
+	set.prerelease = intervalPolicy{}
+	return set, nil
+}
+
+// parseIntervalVersion parses a NuGet or Maven version, e.g. `1.0` or
+// `1.2.3.0`, as described by [parseLooseVersion]. The version is zero
+// filled.
+func parseIntervalVersion(input string) (*Version, error) {
+	v, err := parseLooseVersion(input)
+	if err != nil {
+		return nil, err
+	}
+	return newVersion(v.major, v.minor, v.patch, v.pre), nil
+}
+
+// parseLooseVersion parses a version of one to four components, optionally
+// prefixed with `v`, e.g. `1.2`, `v1.2.3-beta` or `1.2.3.0`. A fourth
+// component is only accepted when it is zero. The components that are
+// present are flagged as parsed, and a version without a patch component is
+// partial.
+func parseLooseVersion(input string) (*Version, error) {
+	matches := looseVersionPattern.FindStringSubmatch(input)
+	if matches == nil {
+		if input != "" && isAlphaChar(input[0]) == true {
+			return nil, fmt.Errorf("%w: `%s`", ErrRangeAlpha, input)
+		}
+		return nil, fmt.Errorf("%w: invalid version `%s`", ErrRangeSyntax, input)
+	}
+
+	v := &Version{pre: matches[5]}
+	components := []*int{&v.major, &v.minor, &v.patch}
+	parsed := []*bool{&v.majorParsed, &v.minorParsed, &v.patchParsed}
+	for i, component := range matches[1:5] {
+		if component == "" {
+			continue
+		}
+		number, err := strconv.Atoi(component)
+		if err != nil {
+			return nil, fmt.Errorf("%w: `%s`: %w", ErrRangeSyntax, input, err)
+		}
+		if i == 3 {
+			if number != 0 {
+				return nil, fmt.Errorf("%w: `%s` has a fourth component that is not zero", ErrRangeSyntax, input)
+			}
+			continue
+		}
+		*components[i] = number
+		*parsed[i] = true
+	}
+	v.partial = v.patchParsed == false
+	return v, nil
+}
+
+// IntervalNotation renders the range in the interval notation of NuGet and
+// Maven, e.g. `>=1.2.3 <2.0.0-0 || 3.x` is rendered as
+// `[1.2.3,2.0.0-0),[3.0.0,4.0.0)`. The notation does not have a
+// pre-release rule, so only the bounds of the range are rendered, as
+// reported by [Range.Intervals]. A range that does not contain any versions
+// is rendered as the empty interval `(0.0.0,0.0.0)`, since the notation does
+// not allow an empty string.
+func (r *Range) IntervalNotation() string {
+	intervals := r.Intervals()
+	if len(intervals) == 0 {
+		return "(0.0.0,0.0.0)"
+	}
+	rendered := make([]string, 0, len(intervals))
+	for _, i := range intervals {
+		if i.Lower.Version != nil && i.Upper.Version != nil && i.Lower.Version.Equals(i.Upper.Version) == true {
+			rendered = append(rendered, "["+i.Lower.Version.String()+"]")
+			continue
+		}
+
+		builder := strings.Builder{}
+		if i.Lower.Inclusive == true && i.Lower.Version != nil {
+			builder.WriteString("[")
+		} else {
+			builder.WriteString("(")
+		}
+		if i.Lower.Version != nil {
+			builder.WriteString(i.Lower.Version.String())
+		}
+		builder.WriteString(",")
+		if i.Upper.Version != nil {
+			builder.WriteString(i.Upper.Version.String())
+		}
+		if i.Upper.Inclusive == true && i.Upper.Version != nil {
+			builder.WriteString("]")
+		} else {
+			builder.WriteString(")")
+		}
+		rendered = append(rendered, builder.String())
+	}
+	return strings.Join(rendered, ",")
+}
@@ -0,0 +1,138 @@
+package semver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseRange_IntervalNotation(t *testing.T) {
+	testCases := []struct {
+		input    string
+		dialect  Dialect
+		expected string
+	}{
+		{input: "[1.0,2.0)", dialect: DialectNuGet, expected: ">=1.0.0 <2.0.0"},
+		{input: "(1.0,2.0]", dialect: DialectNuGet, expected: ">1.0.0 <=2.0.0"},
+		{input: "(,1.5]", dialect: DialectNuGet, expected: "<=1.5.0"},
+		{input: "[1.5,)", dialect: DialectNuGet, expected: ">=1.5.0"},
+		{input: "(,)", dialect: DialectNuGet, expected: ">=0.0.0-0"},
+		{input: "[1.2.3]", dialect: DialectNuGet, expected: "=1.2.3"},
+		{input: "[ 1.0 , 2.0 )", dialect: DialectNuGet, expected: ">=1.0.0 <2.0.0"},
+		{input: "[1.0.0.0,2.0.0-beta)", dialect: DialectNuGet, expected: ">=1.0.0 <2.0.0-beta"},
+		{input: "1.0", dialect: DialectNuGet, expected: ">=1.0.0"},
+		{input: "1.0", dialect: DialectMaven, expected: ">=0.0.0-0"},
+		{input: "[1.0,1.2),[1.3,)", dialect: DialectMaven, expected: ">=1.0.0 <1.2.0 || >=1.3.0"},
+		{input: "(,1.0] , [1.2,)", dialect: DialectMaven, expected: "<=1.0.0 || >=1.2.0"},
+		{input: "[1.0-SNAPSHOT]", dialect: DialectMaven, expected: "=1.0.0-SNAPSHOT"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.dialect.String()+" "+testCase.input, func(t *testing.T) {
+			r, err := ParseRange(testCase.input, testCase.dialect)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, r.String())
+		})
+	}
+
+	t.Run("raw keeps the notation", func(t *testing.T) {
+		r, err := ParseRange(" [1.0,1.2), [1.3,) ", DialectMaven)
+		assert.Nil(t, err)
+		assert.Equal(t, "[1.0,1.2),[1.3,)", r.Raw())
+	})
+
+	t.Run("pre-releases within the bounds satisfy the range", func(t *testing.T) {
+		r, _ := ParseRange("[1.0,2.0)", DialectNuGet)
+		testCases := []struct {
+			version  string
+			expected bool
+		}{
+			{version: "1.0.0", expected: true},
+			{version: "1.5.0-beta", expected: true},
+			{version: "2.0.0-rc.1", expected: true},
+			{version: "2.0.0", expected: false},
+			{version: "1.0.0-rc.1", expected: false},
+		}
+		for _, testCase := range testCases {
+			v, _ := VersionFromString(testCase.version)
+			assert.Equal(t, testCase.expected, v.Satisfies(r), testCase.version)
+		}
+
+		min, err := r.MinVersion()
+		assert.Nil(t, err)
+		assert.Equal(t, "1.0.0", min.String())
+
+		r, _ = ParseRange("(1.0,2.0)", DialectNuGet)
+		min, err = r.MinVersion()
+		assert.Nil(t, err)
+		assert.Equal(t, "1.0.1-0", min.String())
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		testCases := []struct {
+			input    string
+			expected error
+		}{
+			{input: "", expected: ErrRangeSyntax},
+			{input: "[1.0,2.0", expected: ErrRangeSyntax},
+			{input: "[1.0,2.0),", expected: ErrRangeSyntax},
+			{input: "[1.0,2.0) [3.0,)", expected: ErrRangeSyntax},
+			{input: "[1.0,2.0,3.0]", expected: ErrRangeSyntax},
+			{input: "(1.0)", expected: ErrRangeSyntax},
+			{input: "[2.0,1.0]", expected: ErrRangeSyntax},
+			{input: "[1.0.0.1]", expected: ErrRangeSyntax},
+			{input: "[1.x,2.0)", expected: ErrRangeSyntax},
+			{input: "[a.b,2.0)", expected: ErrRangeAlpha},
+		}
+		for _, testCase := range testCases {
+			r, err := ParseRange(testCase.input, DialectNuGet)
+			assert.Nil(t, r)
+			assert.ErrorIs(t, err, testCase.expected, testCase.input)
+		}
+
+		r, err := ParseRangeWithOptions("(1.0,1.0)", DialectNuGet, Options{RejectUnsatisfiable: true})
+		assert.Nil(t, r)
+		assert.ErrorIs(t, err, ErrRangeUnsatisfiable)
+	})
+}
+
+func TestRange_IntervalNotation(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{input: "^1.2.3", expected: "[1.2.3,2.0.0-0)"},
+		{input: ">1.2.3 <=2.0.0", expected: "(1.2.3,2.0.0]"},
+		{input: "<1.5.0", expected: "(,1.5.0)"},
+		{input: ">=1.5.0", expected: "[1.5.0,)"},
+		{input: "*", expected: "[0.0.0,)"},
+		{input: "1.2.3", expected: "[1.2.3]"},
+		{input: "1.2.x || >=3.0.0", expected: "[1.2.0,1.3.0),[3.0.0,)"},
+		{input: ">=1.0.0 <2.0.0 !=1.5.0", expected: "[1.0.0,1.5.0),(1.5.0,2.0.0)"},
+		{input: ">2.0.0 <1.0.0", expected: "(0.0.0,0.0.0)"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			r, err := RangeFromString(testCase.input)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, r.IntervalNotation())
+		})
+	}
+
+	t.Run("round trips through the nuget dialect", func(t *testing.T) {
+		input := "(,1.0.0],[1.2.3],(2.0.0,3.0.0-0)"
+		r, err := ParseRange(input, DialectNuGet)
+		assert.Nil(t, err)
+		assert.Equal(t, input, r.IntervalNotation())
+	})
+
+	t.Run("an empty range round trips", func(t *testing.T) {
+		empty, _ := RangeFromString(">2.0.0 <1.0.0")
+		for _, dialect := range []Dialect{DialectNuGet, DialectMaven} {
+			r, err := ParseRange(empty.IntervalNotation(), dialect)
+			assert.Nil(t, err)
+			assert.Equal(t, true, r.IsEmpty())
+			assert.Equal(t, "(0.0.0,0.0.0)", r.IntervalNotation())
+		}
+	})
+}
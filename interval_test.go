@@ -0,0 +1,98 @@
+package semver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRange_Intervals(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected []string
+	}{
+		{input: "^1.2.3", expected: []string{"[1.2.3, 2.0.0-0)"}},
+		{input: "1.2.3", expected: []string{"[1.2.3, 1.2.3]"}},
+		{input: "<1.0.0", expected: []string{"(, 1.0.0)"}},
+		{input: ">1.0.0", expected: []string{"(1.0.0, )"}},
+		{input: "*", expected: []string{"[0.0.0, )"}},
+		{input: ">=0.0.0-0", expected: []string{"(, )"}},
+		{
+			input:    "^2.0.0 || ^1.0.0 || 1.5.0",
+			expected: []string{"[1.0.0, 2.0.0-0)", "[2.0.0, 3.0.0-0)"},
+		},
+		{
+			input:    ">=1.0.0 <1.5.0 || >=1.5.0 <=2.0.0 || >2.0.0 <3.0.0",
+			expected: []string{"[1.0.0, 3.0.0)"},
+		},
+		{
+			input:    "<1.0.0 || >1.0.0",
+			expected: []string{"(, 1.0.0)", "(1.0.0, )"},
+		},
+		{
+			input:    "^2.0.0 !=2.3.1 !=2.4.0",
+			expected: []string{"[2.0.0, 2.3.1)", "(2.3.1, 2.4.0)", "(2.4.0, 3.0.0-0)"},
+		},
+		{input: "!=1.0.0", expected: []string{"(, 1.0.0)", "(1.0.0, )"}},
+		{input: "^2.0.0 !=1.0.0", expected: []string{"[2.0.0, 3.0.0-0)"}},
+		{input: ">=2.0.0 <1.0.0", expected: []string{}},
+		{input: ">2.0.0 <2.0.0 || <0.0.0-0", expected: []string{}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			r, err := RangeFromString(testCase.input)
+			assert.Nil(t, err)
+
+			found := make([]string, 0)
+			for _, i := range r.Intervals() {
+				found = append(found, i.String())
+			}
+			assert.Equal(t, testCase.expected, found)
+		})
+	}
+}
+
+func TestRange_Bounds(t *testing.T) {
+	r, _ := RangeFromString("<1.0.0 || ^2.0.0")
+	lower, ok := r.LowerBound()
+	assert.Equal(t, true, ok)
+	assert.Equal(t, true, lower.Unbounded())
+
+	upper, ok := r.UpperBound()
+	assert.Equal(t, true, ok)
+	assert.Equal(t, false, upper.Unbounded())
+	assert.Equal(t, false, upper.Inclusive)
+	assert.Equal(t, "3.0.0-0", upper.Version.String())
+
+	r, _ = RangeFromString("1.2.3 - 2.3.4")
+	lower, _ = r.LowerBound()
+	assert.Equal(t, "1.2.3", lower.Version.String())
+	assert.Equal(t, true, lower.Inclusive)
+	upper, _ = r.UpperBound()
+	assert.Equal(t, "2.3.4", upper.Version.String())
+	assert.Equal(t, true, upper.Inclusive)
+
+	r, _ = RangeFromString(">1.0.0")
+	upper, _ = r.UpperBound()
+	assert.Equal(t, true, upper.Unbounded())
+
+	r, _ = RangeFromString(">=2.0.0 <1.0.0")
+	_, ok = r.LowerBound()
+	assert.Equal(t, false, ok)
+	_, ok = r.UpperBound()
+	assert.Equal(t, false, ok)
+}
+
+func TestInterval_Contains(t *testing.T) {
+	r, _ := RangeFromString("^1.2.3")
+	i := r.Intervals()[0]
+
+	v, _ := VersionFromString("1.2.3")
+	assert.Equal(t, true, i.Contains(v))
+	v, _ = VersionFromString("1.9.9-beta")
+	assert.Equal(t, true, i.Contains(v))
+	v, _ = VersionFromString("2.0.0-0")
+	assert.Equal(t, false, i.Contains(v))
+	v, _ = VersionFromString("1.2.2")
+	assert.Equal(t, false, i.Contains(v))
+}
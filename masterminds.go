@@ -0,0 +1,233 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// mastermindsConstraint is a single constraint of a Masterminds constraint
+// string, e.g. `>= 1.2.x`.
+type mastermindsConstraint struct {
+	operator string
+	// version is the zero filled version of the constraint.
+	version *Version
+	// dirty indicates the version is partial, or contains a wildcard. When
+	// it is, minorDirty or patchDirty indicate the first component that is
+	// missing, unless the major component is missing.
+	dirty      bool
+	minorDirty bool
+	patchDirty bool
+}
+
+var mastermindsVersionPattern = regexp.MustCompile(`^v?(\d+|[xX*])(?:\.(\d+|[xX*]))?(?:\.(\d+|[xX*]))?` +
+	`(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?(?:\+[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?$`)
+
+var mastermindsCommaPattern = regexp.MustCompile(`^,|,\s*,|,$`)
+
+// parseMastermindsRange parses a constraint of Masterminds/semver, as used
+// by Helm, e.g. `>= 1.2, < 3.0.0 || >= 4.2.3`. Masterminds differs from npm
+// in a few ways:
+//
+//   - comparators are separated by commas or whitespace, and sets by `||`
+//   - `=>` and `=<` are aliases of `>=` and `<=`, and `~>` of `~`
+//   - a partial version is expanded according to its operator, e.g. `>1.2`
+//     means `>=1.3.0`, `<=1.2` means `<1.3.0`, and `!=1.2` excludes every
+//     `1.2.x` release
+//   - `~0.0.0` and `~*` match any version, while `^*` means `^0.0.0`
+//
+// A set that names a pre-release allows any pre-release within its bounds,
+// e.g. `>=1.2.3-beta` is satisfied by `1.5.0-alpha`. A constraint such as
+// `!=1.2` only excludes the releases of `1.2.x`, so its pre-releases still
+// satisfy the set when pre-releases are allowed, as in Masterminds, while
+// `!=1.2-beta` only excludes the `1.2.x-beta` pre-releases.
+//
+// See https://github.com/Masterminds/semver#checking-version-constraints
+func parseMastermindsRange(input string, opts Options) (*Range, error) {
+	trimmed := strings.TrimSpace(input)
+	if trimmed == "" {
+		return nil, fmt.Errorf("%w: empty masterminds constraint", ErrRangeSyntax)
+	}
+
+	sets := make([]ComparatorSet, 0)
+	raw := make([]string, 0)
+	for _, part := range strings.Split(trimmed, "||") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return nil, fmt.Errorf("%w: empty set in `%s`", ErrRangeSyntax, trimmed)
+		}
+		set, err := parseMastermindsSet(part)
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, set)
+		raw = append(raw, part)
+	}
+
+	return rangeFromSets(sets, raw, opts)
+}
+
+// parseMastermindsSet parses the constraints between `||`.
+func parseMastermindsSet(input string) (ComparatorSet, error) {
+	if mastermindsCommaPattern.MatchString(input) == true {
+		return ComparatorSet{}, fmt.Errorf("%w: empty constraint in `%s`", ErrRangeSyntax, input)
+	}
+	tokens := strings.FieldsFunc(input, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+
+	// Join operators separated from their version by whitespace, e.g.
+	// `>= 1.2`.
+	terms := make([]string, 0, len(tokens))
+	for i := 0; i < len(tokens); i += 1 {
+		term := tokens[i]
+		for strings.Trim(term, "<>=!~^") == "" && i+1 < len(tokens) && strings.Trim(tokens[i+1], "<>=!~^") != "" {
+			i += 1
+			term += tokens[i]
+		}
+		terms = append(terms, term)
+	}
+
+	// Rewrite hyphen ranges, e.g. `1.2 - 1.4.5` means `>=1.2 <=1.4.5`.
+	for i := 0; i < len(terms); i += 1 {
+		if terms[i] != "-" {
+			continue
+		}
+		if i == 0 || i+1 == len(terms) {
+			return ComparatorSet{}, fmt.Errorf("%w: incomplete hyphen range in `%s`", ErrRangeSyntax, input)
+		}
+		rewritten := append([]string{}, terms[:i-1]...)
+		rewritten = append(rewritten, ">="+terms[i-1], "<="+terms[i+1])
+		terms = append(rewritten, terms[i+2:]...)
+	}
+
+	comparators := make([]*Comparator, 0, len(terms))
+	policy := namedPrereleasePolicy{}
+	for _, term := range terms {
+		constraint, err := parseMastermindsConstraint(term)
+		if err != nil {
+			return ComparatorSet{}, err
+		}
+		if constraint.version.pre != "" && policy.namedBy == "" {
+			policy.namedBy = term
+		}
+		comparators = append(comparators, constraint.desugar()...)
+	}
+
+	set := newComparatorSet(comparators...)
+	set.prerelease = policy
+	return set, nil
+}
+func parseMastermindsConstraint(input string) (mastermindsConstraint, error) {
+	rest := strings.TrimLeft(input, "=<>!~^")
+	constraint := mastermindsConstraint{operator: input[:len(input)-len(rest)]}
+	switch constraint.operator {
+	case "", "=", "!=", ">", "<", ">=", "<=", "~", "^":
+	case "=>":
+		constraint.operator = ">="
+	case "=<":
+		constraint.operator = "<="
+	case "~>":
+		constraint.operator = "~"
+	default:
+		return constraint, fmt.Errorf("%w: `%s`", ErrRangeOperator, constraint.operator)
+	}
+
+	matches := mastermindsVersionPattern.FindStringSubmatch(rest)
+	if matches == nil {
+		if rest != "" && isAlphaChar(rest[0]) == true && isXRangeChar(rest[0]) == false {
+			return constraint, fmt.Errorf("%w: `%s`", ErrRangeAlpha, rest)
+		}
+		return constraint, fmt.Errorf("%w: invalid constraint `%s`", ErrRangeSyntax, input)
+	}
+
+	components := make([]int, 3)
+	for i, component := range matches[1:4] {
+		if component == "" || isXRangeChar(component[0]) == true {
+			constraint.dirty = true
+			constraint.minorDirty = i == 1
+			constraint.patchDirty = i == 2
+			break
+		}
+		number, err := strconv.Atoi(component)
+		if err != nil {
+			return constraint, fmt.Errorf("%w: `%s`", ErrRangeSyntax, input)
+		}
+		components[i] = number
+	}
+	constraint.version = newVersion(components[0], components[1], components[2], matches[4])
+	return constraint, nil
+}
+
+// desugar expands the constraint into comparators, following the checks of
+// Masterminds/semver.
+func (c mastermindsConstraint) desugar() []*Comparator {
+	v := c.version
+	lower := &Comparator{operator: OperatorGreaterThanEqual, version: v}
+	nextMajor := upperBoundVersion(v.major+1, 0, 0)
+	nextMinor := upperBoundVersion(v.major, v.minor+1, 0)
+
+	switch {
+	case c.operator == "" || c.operator == "=":
+		if c.dirty == false {
+			return []*Comparator{{operator: OperatorEqual, version: v}}
+		}
+		return mastermindsConstraint{operator: "~", version: v, dirty: true, minorDirty: c.minorDirty, patchDirty: c.patchDirty}.desugar()
+	case c.operator == "~":
+		if v.major == 0 && v.minor == 0 && v.patch == 0 && c.minorDirty == false && c.patchDirty == false {
+			return []*Comparator{lower}
+		}
+		if c.minorDirty == true {
+			return []*Comparator{lower, {operator: OperatorLessThan, version: nextMajor}}
+		}
+		return []*Comparator{lower, {operator: OperatorLessThan, version: nextMinor}}
+	case c.operator == "^":
+		switch {
+		case v.major > 0 || c.minorDirty == true:
+			return []*Comparator{lower, {operator: OperatorLessThan, version: nextMajor}}
+		case v.minor > 0 || c.patchDirty == true:
+			return []*Comparator{lower, {operator: OperatorLessThan, version: nextMinor}}
+		default:
+			return []*Comparator{lower, {operator: OperatorLessThan, version: upperBoundVersion(0, 0, v.patch+1)}}
+		}
+	case c.operator == "!=":
+		if c.minorDirty == true || c.patchDirty == true {
+			// The partial version excludes a span of versions, see
+			// [inExcludedSpan].
+			partial := &Version{
+				major:       v.major,
+				minor:       v.minor,
+				majorParsed: true,
+				minorParsed: c.patchDirty,
+				partial:     true,
+				pre:         v.pre,
+			}
+			return []*Comparator{{operator: OperatorNotEqual, version: partial}}
+		}
+		return []*Comparator{{operator: OperatorNotEqual, version: v}}
+	case c.operator == ">":
+		switch {
+		case c.minorDirty == true:
+			return []*Comparator{{operator: OperatorGreaterThanEqual, version: nextMajor}}
+		case c.patchDirty == true:
+			return []*Comparator{{operator: OperatorGreaterThanEqual, version: nextMinor}}
+		default:
+			return []*Comparator{{operator: OperatorGreaterThan, version: v}}
+		}
+	case c.operator == "<":
+		return []*Comparator{{operator: OperatorLessThan, version: v}}
+	case c.operator == ">=":
+		return []*Comparator{lower}
+	default:
+		// <=
+		switch {
+		case c.dirty == false:
+			return []*Comparator{{operator: OperatorLessThanEqual, version: v}}
+		case c.minorDirty == true:
+			return []*Comparator{{operator: OperatorLessThan, version: nextMajor}}
+		default:
+			return []*Comparator{{operator: OperatorLessThan, version: nextMinor}}
+		}
+	}
+}
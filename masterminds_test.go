@@ -0,0 +1,200 @@
+package semver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// mastermindsVersions are checked against every constraint of the
+// conformance table in TestParseRange_Masterminds.
+var mastermindsVersions = []string{
+	"0.0.0", "0.0.3", "0.1.0", "0.2.3", "0.2.9", "0.3.0",
+	"1.0.0", "1.2.0", "1.2.2", "1.2.3-beta", "1.2.3", "1.2.9", "1.3.0-alpha", "1.3.0", "1.4.5", "1.4.9",
+	"2.0.0-alpha", "2.0.0", "2.9.9", "3.0.0", "4.2.3",
+}
+
+func TestParseRange_Masterminds(t *testing.T) {
+	// The expected matches were recorded from Masterminds/semver v3.4.0.
+	testCases := []struct {
+		constraint string
+		matches    []string
+	}{
+		{constraint: "1.2.3", matches: []string{"1.2.3"}},
+		{constraint: "=1.2.3", matches: []string{"1.2.3"}},
+		{constraint: "1.2", matches: []string{"1.2.0", "1.2.2", "1.2.3", "1.2.9"}},
+		{constraint: "1", matches: []string{"1.0.0", "1.2.0", "1.2.2", "1.2.3", "1.2.9", "1.3.0", "1.4.5", "1.4.9"}},
+		{constraint: "1.x", matches: []string{"1.0.0", "1.2.0", "1.2.2", "1.2.3", "1.2.9", "1.3.0", "1.4.5", "1.4.9"}},
+		{constraint: "1.2.x", matches: []string{"1.2.0", "1.2.2", "1.2.3", "1.2.9"}},
+		{constraint: "*", matches: []string{"0.0.0", "0.0.3", "0.1.0", "0.2.3", "0.2.9", "0.3.0", "1.0.0", "1.2.0", "1.2.2", "1.2.3", "1.2.9", "1.3.0", "1.4.5", "1.4.9", "2.0.0", "2.9.9", "3.0.0", "4.2.3"}},
+		{constraint: "x", matches: []string{"0.0.0", "0.0.3", "0.1.0", "0.2.3", "0.2.9", "0.3.0", "1.0.0", "1.2.0", "1.2.2", "1.2.3", "1.2.9", "1.3.0", "1.4.5", "1.4.9", "2.0.0", "2.9.9", "3.0.0", "4.2.3"}},
+		{constraint: "=1.2", matches: []string{"1.2.0", "1.2.2", "1.2.3", "1.2.9"}},
+		{constraint: "v1.2.3", matches: []string{"1.2.3"}},
+		{constraint: "!=1.2.3", matches: []string{"0.0.0", "0.0.3", "0.1.0", "0.2.3", "0.2.9", "0.3.0", "1.0.0", "1.2.0", "1.2.2", "1.2.9", "1.3.0", "1.4.5", "1.4.9", "2.0.0", "2.9.9", "3.0.0", "4.2.3"}},
+		{constraint: "!=1.2", matches: []string{"0.0.0", "0.0.3", "0.1.0", "0.2.3", "0.2.9", "0.3.0", "1.0.0", "1.3.0", "1.4.5", "1.4.9", "2.0.0", "2.9.9", "3.0.0", "4.2.3"}},
+		{constraint: "!=1", matches: []string{"0.0.0", "0.0.3", "0.1.0", "0.2.3", "0.2.9", "0.3.0", "2.0.0", "2.9.9", "3.0.0", "4.2.3"}},
+		{constraint: "!=1.x", matches: []string{"0.0.0", "0.0.3", "0.1.0", "0.2.3", "0.2.9", "0.3.0", "2.0.0", "2.9.9", "3.0.0", "4.2.3"}},
+		{constraint: "!=*", matches: []string{"0.0.3", "0.1.0", "0.2.3", "0.2.9", "0.3.0", "1.0.0", "1.2.0", "1.2.2", "1.2.3", "1.2.9", "1.3.0", "1.4.5", "1.4.9", "2.0.0", "2.9.9", "3.0.0", "4.2.3"}},
+		{constraint: ">1.2.3", matches: []string{"1.2.9", "1.3.0", "1.4.5", "1.4.9", "2.0.0", "2.9.9", "3.0.0", "4.2.3"}},
+		{constraint: ">1.2", matches: []string{"1.3.0", "1.4.5", "1.4.9", "2.0.0", "2.9.9", "3.0.0", "4.2.3"}},
+		{constraint: ">1", matches: []string{"2.0.0", "2.9.9", "3.0.0", "4.2.3"}},
+		{constraint: ">1.x", matches: []string{"2.0.0", "2.9.9", "3.0.0", "4.2.3"}},
+		{constraint: ">*", matches: []string{"0.0.3", "0.1.0", "0.2.3", "0.2.9", "0.3.0", "1.0.0", "1.2.0", "1.2.2", "1.2.3", "1.2.9", "1.3.0", "1.4.5", "1.4.9", "2.0.0", "2.9.9", "3.0.0", "4.2.3"}},
+		{constraint: "<1.2.3", matches: []string{"0.0.0", "0.0.3", "0.1.0", "0.2.3", "0.2.9", "0.3.0", "1.0.0", "1.2.0", "1.2.2"}},
+		{constraint: "<1.2", matches: []string{"0.0.0", "0.0.3", "0.1.0", "0.2.3", "0.2.9", "0.3.0", "1.0.0"}},
+		{constraint: "<1", matches: []string{"0.0.0", "0.0.3", "0.1.0", "0.2.3", "0.2.9", "0.3.0"}},
+		{constraint: "<*", matches: []string{}},
+		{constraint: ">=1.2.3", matches: []string{"1.2.3", "1.2.9", "1.3.0", "1.4.5", "1.4.9", "2.0.0", "2.9.9", "3.0.0", "4.2.3"}},
+		{constraint: ">=1.2", matches: []string{"1.2.0", "1.2.2", "1.2.3", "1.2.9", "1.3.0", "1.4.5", "1.4.9", "2.0.0", "2.9.9", "3.0.0", "4.2.3"}},
+		{constraint: "=>1.2", matches: []string{"1.2.0", "1.2.2", "1.2.3", "1.2.9", "1.3.0", "1.4.5", "1.4.9", "2.0.0", "2.9.9", "3.0.0", "4.2.3"}},
+		{constraint: ">=*", matches: []string{"0.0.0", "0.0.3", "0.1.0", "0.2.3", "0.2.9", "0.3.0", "1.0.0", "1.2.0", "1.2.2", "1.2.3", "1.2.9", "1.3.0", "1.4.5", "1.4.9", "2.0.0", "2.9.9", "3.0.0", "4.2.3"}},
+		{constraint: "<=1.2.3", matches: []string{"0.0.0", "0.0.3", "0.1.0", "0.2.3", "0.2.9", "0.3.0", "1.0.0", "1.2.0", "1.2.2", "1.2.3"}},
+		{constraint: "<=1.2", matches: []string{"0.0.0", "0.0.3", "0.1.0", "0.2.3", "0.2.9", "0.3.0", "1.0.0", "1.2.0", "1.2.2", "1.2.3", "1.2.9"}},
+		{constraint: "<=1", matches: []string{"0.0.0", "0.0.3", "0.1.0", "0.2.3", "0.2.9", "0.3.0", "1.0.0", "1.2.0", "1.2.2", "1.2.3", "1.2.9", "1.3.0", "1.4.5", "1.4.9"}},
+		{constraint: "=<1.2", matches: []string{"0.0.0", "0.0.3", "0.1.0", "0.2.3", "0.2.9", "0.3.0", "1.0.0", "1.2.0", "1.2.2", "1.2.3", "1.2.9"}},
+		{constraint: "<=*", matches: []string{"0.0.0", "0.0.3"}},
+		{constraint: "~1.2.3", matches: []string{"1.2.3", "1.2.9"}},
+		{constraint: "~1.2", matches: []string{"1.2.0", "1.2.2", "1.2.3", "1.2.9"}},
+		{constraint: "~1", matches: []string{"1.0.0", "1.2.0", "1.2.2", "1.2.3", "1.2.9", "1.3.0", "1.4.5", "1.4.9"}},
+		{constraint: "~>1.2.3", matches: []string{"1.2.3", "1.2.9"}},
+		{constraint: "~>1.2", matches: []string{"1.2.0", "1.2.2", "1.2.3", "1.2.9"}},
+		{constraint: "~0.0.0", matches: []string{"0.0.0", "0.0.3", "0.1.0", "0.2.3", "0.2.9", "0.3.0", "1.0.0", "1.2.0", "1.2.2", "1.2.3", "1.2.9", "1.3.0", "1.4.5", "1.4.9", "2.0.0", "2.9.9", "3.0.0", "4.2.3"}},
+		{constraint: "~0", matches: []string{"0.0.0", "0.0.3", "0.1.0", "0.2.3", "0.2.9", "0.3.0"}},
+		{constraint: "~0.0", matches: []string{"0.0.0", "0.0.3"}},
+		{constraint: "~*", matches: []string{"0.0.0", "0.0.3", "0.1.0", "0.2.3", "0.2.9", "0.3.0", "1.0.0", "1.2.0", "1.2.2", "1.2.3", "1.2.9", "1.3.0", "1.4.5", "1.4.9", "2.0.0", "2.9.9", "3.0.0", "4.2.3"}},
+		{constraint: "^1.2.3", matches: []string{"1.2.3", "1.2.9", "1.3.0", "1.4.5", "1.4.9"}},
+		{constraint: "^1.2", matches: []string{"1.2.0", "1.2.2", "1.2.3", "1.2.9", "1.3.0", "1.4.5", "1.4.9"}},
+		{constraint: "^1", matches: []string{"1.0.0", "1.2.0", "1.2.2", "1.2.3", "1.2.9", "1.3.0", "1.4.5", "1.4.9"}},
+		{constraint: "^0.2.3", matches: []string{"0.2.3", "0.2.9"}},
+		{constraint: "^0.2", matches: []string{"0.2.3", "0.2.9"}},
+		{constraint: "^0.0.3", matches: []string{"0.0.3"}},
+		{constraint: "^0.0", matches: []string{"0.0.0", "0.0.3"}},
+		{constraint: "^0", matches: []string{"0.0.0", "0.0.3", "0.1.0", "0.2.3", "0.2.9", "0.3.0"}},
+		{constraint: "^*", matches: []string{"0.0.0"}},
+		{constraint: ">= 1.2, < 3.0.0 || >= 4.2.3", matches: []string{"1.2.0", "1.2.2", "1.2.3", "1.2.9", "1.3.0", "1.4.5", "1.4.9", "2.0.0", "2.9.9", "4.2.3"}},
+		{constraint: ">=1.2 <3", matches: []string{"1.2.0", "1.2.2", "1.2.3", "1.2.9", "1.3.0", "1.4.5", "1.4.9", "2.0.0", "2.9.9"}},
+		{constraint: "1.2 - 1.4.5", matches: []string{"1.2.0", "1.2.2", "1.2.3", "1.2.9", "1.3.0", "1.4.5"}},
+		{constraint: "1.2 - 1.4", matches: []string{"1.2.0", "1.2.2", "1.2.3", "1.2.9", "1.3.0", "1.4.5", "1.4.9"}},
+		{constraint: "1.2 - 1.4.5, !=1.3.0", matches: []string{"1.2.0", "1.2.2", "1.2.3", "1.2.9", "1.4.5"}},
+		{constraint: ">=1.0.0, !=1.2", matches: []string{"1.0.0", "1.3.0", "1.4.5", "1.4.9", "2.0.0", "2.9.9", "3.0.0", "4.2.3"}},
+		{constraint: ">=1.0.0 !=1.x <3", matches: []string{"2.0.0", "2.9.9"}},
+		{constraint: ">=1.2.3-beta", matches: []string{"1.2.3-beta", "1.2.3", "1.2.9", "1.3.0-alpha", "1.3.0", "1.4.5", "1.4.9", "2.0.0-alpha", "2.0.0", "2.9.9", "3.0.0", "4.2.3"}},
+		{constraint: ">=1.2.3-beta, <2.0.0", matches: []string{"1.2.3-beta", "1.2.3", "1.2.9", "1.3.0-alpha", "1.3.0", "1.4.5", "1.4.9", "2.0.0-alpha"}},
+		{constraint: "^1.2.3-beta", matches: []string{"1.2.3-beta", "1.2.3", "1.2.9", "1.3.0-alpha", "1.3.0", "1.4.5", "1.4.9"}},
+		{constraint: "~1.2.3-beta", matches: []string{"1.2.3-beta", "1.2.3", "1.2.9"}},
+		{constraint: "1.2.3-beta", matches: []string{"1.2.3-beta"}},
+		{constraint: "<2.0.0-0", matches: []string{"0.0.0", "0.0.3", "0.1.0", "0.2.3", "0.2.9", "0.3.0", "1.0.0", "1.2.0", "1.2.2", "1.2.3-beta", "1.2.3", "1.2.9", "1.3.0-alpha", "1.3.0", "1.4.5", "1.4.9"}},
+		{constraint: ">1.2-beta", matches: []string{"1.3.0-alpha", "1.3.0", "1.4.5", "1.4.9", "2.0.0-alpha", "2.0.0", "2.9.9", "3.0.0", "4.2.3"}},
+		{constraint: "!=1.2.3, >=1.0.0-rc", matches: []string{"1.0.0", "1.2.0", "1.2.2", "1.2.3-beta", "1.2.9", "1.3.0-alpha", "1.3.0", "1.4.5", "1.4.9", "2.0.0-alpha", "2.0.0", "2.9.9", "3.0.0", "4.2.3"}},
+		{constraint: "!=1.2-beta", matches: []string{"0.0.0", "0.0.3", "0.1.0", "0.2.3", "0.2.9", "0.3.0", "1.0.0", "1.2.0", "1.2.2", "1.2.3", "1.2.9", "1.3.0-alpha", "1.3.0", "1.4.5", "1.4.9", "2.0.0-alpha", "2.0.0", "2.9.9", "3.0.0", "4.2.3"}},
+		{constraint: "!=1.2.x, >=1.0.0-rc", matches: []string{"1.0.0", "1.2.3-beta", "1.3.0-alpha", "1.3.0", "1.4.5", "1.4.9", "2.0.0-alpha", "2.0.0", "2.9.9", "3.0.0", "4.2.3"}},
+		{constraint: ">=1.0.0-rc !=1", matches: []string{"2.0.0-alpha", "2.0.0", "2.9.9", "3.0.0", "4.2.3"}},
+		{constraint: "!=1.3-alpha, >=1.0.0", matches: []string{"1.0.0", "1.2.0", "1.2.2", "1.2.3-beta", "1.2.3", "1.2.9", "1.3.0", "1.4.5", "1.4.9", "2.0.0-alpha", "2.0.0", "2.9.9", "3.0.0", "4.2.3"}},
+		{constraint: "1.2.3-beta || 2.x", matches: []string{"1.2.3-beta", "2.0.0", "2.9.9"}},
+		{constraint: ">=1.2.3-beta || >=1.0.0 <1.5", matches: []string{"1.0.0", "1.2.0", "1.2.2", "1.2.3-beta", "1.2.3", "1.2.9", "1.3.0-alpha", "1.3.0", "1.4.5", "1.4.9", "2.0.0-alpha", "2.0.0", "2.9.9", "3.0.0", "4.2.3"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.constraint, func(t *testing.T) {
+			r, err := ParseRange(testCase.constraint, DialectMasterminds)
+			assert.Nil(t, err)
+
+			matches := make([]string, 0)
+			for _, version := range mastermindsVersions {
+				v, _ := VersionFromString(version)
+				if v.Satisfies(r) == true {
+					matches = append(matches, version)
+				}
+			}
+			assert.Equal(t, testCase.matches, matches)
+		})
+	}
+
+	t.Run("desugars", func(t *testing.T) {
+		testCases := []struct {
+			input    string
+			expected string
+		}{
+			{input: "1.2", expected: ">=1.2.0 <1.3.0-0"},
+			{input: ">1.2", expected: ">=1.3.0-0"},
+			{input: "=<1.2", expected: "<1.3.0-0"},
+			{input: "~>1.2.3", expected: ">=1.2.3 <1.3.0-0"},
+			{input: "^*", expected: ">=0.0.0 <0.0.1-0"},
+			{input: "1.2 - 1.4.5", expected: ">=1.2.0 <=1.4.5"},
+			{input: ">=1.0.0, !=1.2", expected: ">=1.0.0 !=1.2"},
+			{input: "!=1.x-beta", expected: "!=1-beta"},
+		}
+		for _, testCase := range testCases {
+			r, err := ParseRange(testCase.input, DialectMasterminds)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, r.String(), testCase.input)
+		}
+	})
+
+	t.Run("intervals of a partial !=", func(t *testing.T) {
+		testCases := []struct {
+			input    string
+			expected []string
+		}{
+			{input: ">=1.0.0, !=1.2", expected: []string{"[1.0.0, 1.2.0)", "[1.3.0-0, )"}},
+			{input: "!=1", expected: []string{"(, 1.0.0-0)", "[2.0.0-0, )"}},
+			{input: "1.2.x !=1.2", expected: []string{}},
+		}
+		for _, testCase := range testCases {
+			r, err := ParseRange(testCase.input, DialectMasterminds)
+			assert.Nil(t, err)
+
+			found := make([]string, 0)
+			for _, i := range r.Intervals() {
+				found = append(found, i.String())
+			}
+			assert.Equal(t, testCase.expected, found, testCase.input)
+		}
+	})
+
+	t.Run("raw keeps the constraint", func(t *testing.T) {
+		r, err := ParseRange(" >=1.0.0, !=1.2 ||  2.x ", DialectMasterminds)
+		assert.Nil(t, err)
+		assert.Equal(t, ">=1.0.0, !=1.2 || 2.x", r.Raw())
+
+		explanation := r.Explain(&Version{major: 1, minor: 2, patch: 5})
+		assert.Equal(t, ">=1.0.0, !=1.2", explanation.Sets[0].Set)
+	})
+
+	t.Run("include pre-releases", func(t *testing.T) {
+		r, err := ParseRangeWithOptions("^1.2.3", DialectMasterminds, Options{IncludePrerelease: true})
+		assert.Nil(t, err)
+		v, _ := VersionFromString("1.5.0-alpha")
+		assert.Equal(t, true, v.Satisfies(r))
+
+		r, err = ParseRangeWithOptions(">=1.0.0, !=1.2", DialectMasterminds, Options{IncludePrerelease: true})
+		assert.Nil(t, err)
+		v, _ = VersionFromString("1.2.3-beta")
+		assert.Equal(t, true, v.Satisfies(r))
+		v, _ = VersionFromString("1.2.3")
+		assert.Equal(t, false, v.Satisfies(r))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		testCases := []struct {
+			input    string
+			expected error
+		}{
+			{input: "", expected: ErrRangeSyntax},
+			{input: "1.2 ||", expected: ErrRangeSyntax},
+			{input: "1.2,", expected: ErrRangeSyntax},
+			{input: "1.2,,1.3", expected: ErrRangeSyntax},
+			{input: "1.2 | 1.3", expected: ErrRangeSyntax},
+			{input: "> = 1.2", expected: ErrRangeSyntax},
+			{input: "1.2.3.4", expected: ErrRangeSyntax},
+			{input: "1.2 -", expected: ErrRangeSyntax},
+			{input: "=~1.2", expected: ErrRangeOperator},
+			{input: "<>1.2", expected: ErrRangeOperator},
+			{input: ">= a", expected: ErrRangeAlpha},
+		}
+		for _, testCase := range testCases {
+			r, err := ParseRange(testCase.input, DialectMasterminds)
+			assert.Nil(t, r)
+			assert.ErrorIs(t, err, testCase.expected, testCase.input)
+		}
+	})
+}
@@ -0,0 +1,30 @@
+package semver
+
+import (
+	"fmt"
+)
+
+// MinVersion returns the lowest version that could possibly satisfy the
+// range, e.g. `0.5.0` for `>1.2.3 || ^0.5`. Exclusive bounds resolve to the
+// next possible version, e.g. `1.2.4` for `>1.2.3` and `1.2.3-beta.0` for
+// `>1.2.3-beta`, while honoring the pre-release rule described by
+// [Version.Satisfies] unless the range was parsed with
+// [Options.IncludePrerelease]. If no version can satisfy the range, an error
+// wrapping [ErrRangeUnsatisfiable] is returned.
+func (r *Range) MinVersion() (*Version, error) {
+	var result *Version
+	for _, set := range r.comparators {
+		witness := lowestWitness(r.options, set)
+		if witness == nil {
+			continue
+		}
+		if result == nil || witness.Less(result) == true {
+			result = witness
+		}
+	}
+
+	if result == nil {
+		return nil, fmt.Errorf("%w: `%s`", ErrRangeUnsatisfiable, r)
+	}
+	return newVersion(result.major, result.minor, result.patch, result.pre), nil
+}
@@ -0,0 +1,66 @@
+package semver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRange_MinVersion(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{input: "*", expected: "0.0.0"},
+		{input: "", expected: "0.0.0"},
+		{input: "1.2.3", expected: "1.2.3"},
+		{input: "^1.2.3", expected: "1.2.3"},
+		{input: "~1.2", expected: "1.2.0"},
+		{input: "1.x", expected: "1.0.0"},
+		{input: "1.2.3 - 2.3.4", expected: "1.2.3"},
+		{input: ">1.2.3 || ^0.5", expected: "0.5.0"},
+		{input: ">1.2.3", expected: "1.2.4"},
+		{input: ">=1.2.3 !=1.2.3 !=1.2.4", expected: "1.2.5"},
+		{input: ">=1.2.3-beta <1.3.0 !=1.2.3-beta", expected: "1.2.3-beta.0"},
+		{input: ">=1.2.3 <2.0.0", expected: "1.2.3"},
+		{input: "<2.0.0", expected: "0.0.0"},
+		{input: "<=0.0.0", expected: "0.0.0"},
+		{input: ">1.0.0 || <0.5.0", expected: "0.0.0"},
+
+		// Pre-releases:
+		{input: "^1.2.3-beta.2", expected: "1.2.3-beta.2"},
+		{input: ">1.2.3-beta", expected: "1.2.3-beta.0"},
+		{input: ">=1.2.3-beta <1.2.3-beta.1", expected: "1.2.3-beta"},
+		{input: ">=0.0.0-0", expected: "0.0.0-0"},
+		{input: "<2.0.0-0", expected: "0.0.0"},
+		{input: "<0.0.1-beta", expected: "0.0.0"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			r, err := RangeFromString(testCase.input)
+			assert.Nil(t, err)
+
+			found, err := r.MinVersion()
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, found.String())
+		})
+	}
+
+	t.Run("unsatisfiable ranges", func(t *testing.T) {
+		inputs := []string{
+			"<0.0.0-0",
+			"<0.0.0",
+			">=2.0.0 <1.0.0",
+			">1.0.0 <1.0.0",
+			">=1.0.0 <1.0.0-beta || >2.0.0 <=2.0.0",
+		}
+		for _, input := range inputs {
+			r, err := RangeFromString(input)
+			assert.Nil(t, err)
+
+			found, err := r.MinVersion()
+			assert.Nil(t, found)
+			assert.ErrorIs(t, err, ErrRangeUnsatisfiable)
+		}
+	})
+}
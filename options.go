@@ -0,0 +1,30 @@
+package semver
+
+// Options alters how a [Range] is parsed, and how versions are evaluated
+// against it.
+type Options struct {
+	// IncludePrerelease disables the pre-release rule described by
+	// [Version.Satisfies]. Pre-release versions are then evaluated purely by
+	// their precedence, like any other version.
+	IncludePrerelease bool
+
+	// RejectUnsatisfiable causes parsing to fail when any comparator set in
+	// the range can never be satisfied, e.g. `>=2.0.0 <1.0.0`. The returned
+	// error wraps [ErrRangeUnsatisfiable].
+	RejectUnsatisfiable bool
+
+	// Syntax selects how [Range.String] renders the range.
+	Syntax RangeSyntax
+}
+
+// RangeSyntax identifies a way of rendering a [Range] as text.
+type RangeSyntax int
+
+const (
+	// SyntaxDesugared renders the primitive comparators a range is made
+	// up of, e.g. `>=1.2.3 <2.0.0-0`.
+	SyntaxDesugared RangeSyntax = iota
+	// SyntaxOriginal renders a range in the syntax it was written in, as
+	// returned by [Range.Raw], e.g. `^1.2.3`.
+	SyntaxOriginal
+)
@@ -0,0 +1,92 @@
+package semver
+
+// Direction indicates which side of a [Range] is inspected by [Outside].
+type Direction int
+
+const (
+	// DirectionGreater inspects versions above every version in the range.
+	DirectionGreater Direction = iota
+	// DirectionLess inspects versions below every version in the range.
+	DirectionLess
+)
+
+// Gtr determines if the version is higher than every version that could
+// satisfy the range, e.g. `2.0.0` for `~1.2.2`. Such a range is stale with
+// respect to the version.
+func (r *Range) Gtr(v *Version) bool {
+	return Outside(v, r, DirectionGreater)
+}
+
+// Ltr determines if the version is lower than every version that could
+// satisfy the range, e.g. `1.2.1` for `~1.2.2`.
+func (r *Range) Ltr(v *Version) bool {
+	return Outside(v, r, DirectionLess)
+}
+
+// Outside determines if the version is outside the bounds of the range in
+// the given direction. A version that satisfies the range is never outside
+// of it, and neither is a version that falls between two of the range's
+// comparator sets. This mirrors the `outside` function of npm's
+// `node-semver`. An unknown direction always results in `false`.
+func Outside(v *Version, r *Range, direction Direction) bool {
+	// The algorithm is written as if determining if the version is greater
+	// than the range. For the opposite direction, every comparison is
+	// flipped.
+	var higher, lower, lowerOrEqual func(a *Version, b *Version) bool
+	var operator, inclusiveOperator RangeOperator
+	switch direction {
+	case DirectionGreater:
+		higher = (*Version).Greater
+		lower = (*Version).Less
+		lowerOrEqual = (*Version).LessThanEquals
+		operator = OperatorGreaterThan
+		inclusiveOperator = OperatorGreaterThanEqual
+	case DirectionLess:
+		higher = (*Version).Less
+		lower = (*Version).Greater
+		lowerOrEqual = (*Version).GreaterThanEquals
+		operator = OperatorLessThan
+		inclusiveOperator = OperatorLessThanEqual
+	default:
+		return false
+	}
+
+	if v.Satisfies(r) == true {
+		return false
+	}
+
+	for _, set := range r.comparators {
+		var high, low *Comparator
+		for _, c := range set.comparators {
+			if c.operator == OperatorNotEqual {
+				// An excluded version does not bound the set.
+				continue
+			}
+			if high == nil {
+				high = c
+				low = c
+			}
+			if higher(c.version, high.version) == true {
+				high = c
+			} else if lower(c.version, low.version) == true {
+				low = c
+			}
+		}
+
+		// The set is open-ended in the inspected direction, so the version
+		// can not be beyond it.
+		if high == nil || high.operator == operator || high.operator == inclusiveOperator {
+			return false
+		}
+
+		// The version is not beyond the lowest edge of the set.
+		if (low.operator == OperatorEqual || low.operator == operator) &&
+			lowerOrEqual(v, low.version) == true {
+			return false
+		} else if low.operator == inclusiveOperator && lower(v, low.version) == true {
+			return false
+		}
+	}
+
+	return true
+}
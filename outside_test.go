@@ -0,0 +1,90 @@
+package semver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRange_Gtr(t *testing.T) {
+	testCases := []struct {
+		targetRange string
+		version     string
+		expected    bool
+	}{
+		{targetRange: "~1.2.2", version: "1.3.0", expected: true},
+		{targetRange: "~1.2.2 !=1.2.5", version: "1.3.0", expected: true},
+		{targetRange: "!=1.2.5", version: "1.2.5", expected: false},
+		{targetRange: "~0.6.1-1", version: "0.7.1-1", expected: true},
+		{targetRange: "1.0.0 - 2.0.0", version: "2.0.1", expected: true},
+		{targetRange: "1.0.0", version: "1.0.1-beta1", expected: true},
+		{targetRange: "1.0.0", version: "2.0.0", expected: true},
+		{targetRange: "<=2.0.0", version: "2.1.1", expected: true},
+		{targetRange: "<2.0.0", version: "2.0.0", expected: true},
+		{targetRange: "0.1.20 || 1.2.4", version: "1.2.5", expected: true},
+		{targetRange: "1.2.x || 2.x", version: "3.0.0", expected: true},
+		{targetRange: "^1.2.3", version: "2.0.0-beta", expected: true},
+		{targetRange: "~1.0", version: "1.1.2", expected: true},
+
+		{targetRange: "~0.6.1-1", version: "0.6.1-1", expected: false},
+		{targetRange: "1.0.0 - 2.0.0", version: "1.2.3", expected: false},
+		{targetRange: "0.1.20 || 1.2.4", version: "1.2.3", expected: false},
+		{targetRange: ">=0.1.97", version: "0.1.93", expected: false},
+		{targetRange: ">=1.0.0", version: "3.0.0", expected: false},
+		{targetRange: "^1.2.3", version: "1.0.0", expected: false},
+		{targetRange: "^1.2.3 || >=3.0.0", version: "2.5.0", expected: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.version+" above "+testCase.targetRange, func(t *testing.T) {
+			r, err := RangeFromString(testCase.targetRange)
+			assert.Nil(t, err)
+			v, err := VersionFromString(testCase.version)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, r.Gtr(v))
+			assert.Equal(t, testCase.expected, Outside(v, r, DirectionGreater))
+		})
+	}
+}
+
+func TestRange_Ltr(t *testing.T) {
+	testCases := []struct {
+		targetRange string
+		version     string
+		expected    bool
+	}{
+		{targetRange: "~1.2.2", version: "1.2.1", expected: true},
+		{targetRange: "~0.6.1-1", version: "0.6.1-0", expected: true},
+		{targetRange: "1.0.0 - 2.0.0", version: "0.0.1", expected: true},
+		{targetRange: "1.0.0-beta.2", version: "1.0.0-beta.1", expected: true},
+		{targetRange: "1.0.0", version: "0.0.0", expected: true},
+		{targetRange: ">=2.0.0", version: "1.1.1", expected: true},
+		{targetRange: ">2.0.0", version: "2.0.0", expected: true},
+		{targetRange: "0.1.20 || 1.2.4", version: "0.1.5", expected: true},
+		{targetRange: "1.2.x || 2.x", version: "1.0.0", expected: true},
+		{targetRange: "^1.2.3", version: "1.2.3-beta", expected: true},
+
+		{targetRange: "~1.2.2", version: "1.2.2", expected: false},
+		{targetRange: "0.1.20 || 1.2.4", version: "1.2.3", expected: false},
+		{targetRange: "<=2.0.0", version: "1.0.0", expected: false},
+		{targetRange: "<2.0.0", version: "3.0.0", expected: false},
+		{targetRange: "^1.2.3", version: "2.0.0", expected: false},
+		{targetRange: "<1.0.0 || ^2.0.0", version: "1.5.0", expected: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.version+" below "+testCase.targetRange, func(t *testing.T) {
+			r, err := RangeFromString(testCase.targetRange)
+			assert.Nil(t, err)
+			v, err := VersionFromString(testCase.version)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, r.Ltr(v))
+			assert.Equal(t, testCase.expected, Outside(v, r, DirectionLess))
+		})
+	}
+}
+
+func TestOutside_UnknownDirection(t *testing.T) {
+	r, _ := RangeFromString("^1.2.3")
+	v, _ := VersionFromString("3.0.0")
+	assert.Equal(t, false, Outside(v, r, Direction(42)))
+}
@@ -0,0 +1,566 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrPEP440Version   = errors.New("invalid PEP 440 version")
+	ErrPEP440Specifier = errors.New("invalid PEP 440 specifier")
+)
+
+// PEP440Version is a Python package version as described by PEP 440, e.g.
+// `1!2.0.post2`, `2.0.0rc1` or `1.0+ubuntu1`. Versions are ordered by the
+// rules of PEP 440 rather than semver precedence, see
+// [PEP440Version.Compare].
+//
+// See https://peps.python.org/pep-0440/
+type PEP440Version struct {
+	epoch   int
+	release []int
+
+	// preLabel is one of `a`, `b` or `rc`, or empty when the version is not
+	// a pre-release.
+	preLabel  string
+	preNumber int
+
+	hasPost    bool
+	postNumber int
+
+	hasDev    bool
+	devNumber int
+
+	// local holds the lower cased segments of the local version label, e.g.
+	// `ubuntu` and `1` for `+ubuntu.1`.
+	local []string
+}
+
+var pep440VersionPattern = regexp.MustCompile(`^(?i)v?(?:(?P<epoch>\d+)!)?(?P<release>\d+(?:\.\d+)*)` +
+	`(?:[-_.]?(?P<pre_l>alpha|a|beta|b|preview|pre|c|rc)[-_.]?(?P<pre_n>\d+)?)?` +
+	`(?:-(?P<post_n1>\d+)|[-_.]?(?P<post_l>post|rev|r)[-_.]?(?P<post_n2>\d+)?)?` +
+	`(?:[-_.]?(?P<dev_l>dev)[-_.]?(?P<dev_n>\d+)?)?` +
+	`(?:\+(?P<local>[a-z0-9]+(?:[-_.][a-z0-9]+)*))?$`)
+
+// PEP440VersionFromString parses a PEP 440 version. Alternative spellings
+// accepted by PEP 440 are normalized, e.g. `1.0-ALPHA.1` is parsed as
+// `1.0a1`, and `1.0-1` as `1.0.post1`.
+func PEP440VersionFromString(input string) (*PEP440Version, error) {
+	matches := pep440VersionPattern.FindStringSubmatch(strings.TrimSpace(input))
+	if matches == nil {
+		return nil, fmt.Errorf("%w: `%s`", ErrPEP440Version, input)
+	}
+	group := func(name string) string {
+		return matches[pep440VersionPattern.SubexpIndex(name)]
+	}
+	number := func(name string) (int, error) {
+		if group(name) == "" {
+			return 0, nil
+		}
+		parsed, err := strconv.Atoi(group(name))
+		if err != nil {
+			return 0, fmt.Errorf("%w: `%s`: %w", ErrPEP440Version, input, err)
+		}
+		return parsed, nil
+	}
+
+	v := &PEP440Version{
+		hasPost: group("post_n1") != "" || group("post_l") != "",
+		hasDev:  group("dev_l") != "",
+	}
+	var err error
+	if v.epoch, err = number("epoch"); err != nil {
+		return nil, err
+	}
+	if v.preNumber, err = number("pre_n"); err != nil {
+		return nil, err
+	}
+	if v.postNumber, err = number("post_n1"); err != nil {
+		return nil, err
+	}
+	if group("post_l") != "" {
+		if v.postNumber, err = number("post_n2"); err != nil {
+			return nil, err
+		}
+	}
+	if v.devNumber, err = number("dev_n"); err != nil {
+		return nil, err
+	}
+
+	for _, component := range strings.Split(group("release"), ".") {
+		parsed, err := strconv.Atoi(component)
+		if err != nil {
+			return nil, fmt.Errorf("%w: `%s`: %w", ErrPEP440Version, input, err)
+		}
+		v.release = append(v.release, parsed)
+	}
+
+	switch strings.ToLower(group("pre_l")) {
+	case "":
+	case "alpha", "a":
+		v.preLabel = "a"
+	case "beta", "b":
+		v.preLabel = "b"
+	default:
+		v.preLabel = "rc"
+	}
+
+	if group("local") != "" {
+		v.local = strings.FieldsFunc(strings.ToLower(group("local")), func(r rune) bool {
+			return r == '-' || r == '_' || r == '.'
+		})
+	}
+
+	return v, nil
+}
+
+// String renders the version in the normalized form described by PEP 440,
+// e.g. `1!2.0a1.post2.dev3+ubuntu.1`.
+func (v *PEP440Version) String() string {
+	builder := strings.Builder{}
+	builder.WriteString(v.public())
+	if len(v.local) > 0 {
+		builder.WriteString("+" + strings.Join(v.local, "."))
+	}
+	return builder.String()
+}
+
+// public renders the version without its local version label.
+func (v *PEP440Version) public() string {
+	builder := strings.Builder{}
+	if v.epoch != 0 {
+		builder.WriteString(fmt.Sprintf("%d!", v.epoch))
+	}
+	release := make([]string, 0, len(v.release))
+	for _, component := range v.release {
+		release = append(release, strconv.Itoa(component))
+	}
+	builder.WriteString(strings.Join(release, "."))
+	if v.preLabel != "" {
+		builder.WriteString(fmt.Sprintf("%s%d", v.preLabel, v.preNumber))
+	}
+	if v.hasPost == true {
+		builder.WriteString(fmt.Sprintf(".post%d", v.postNumber))
+	}
+	if v.hasDev == true {
+		builder.WriteString(fmt.Sprintf(".dev%d", v.devNumber))
+	}
+	return builder.String()
+}
+
+// Compare evaluates the ordinality between two versions according to PEP
+// 440. Results:
+//   - `v > other => 1`
+//   - `v < other => -1`
+//   - `v == other => 0`
+//
+// Trailing zeros of the release segment are ignored, e.g. `1.0` equals
+// `1.0.0`. Development releases come before pre-releases, which come before
+// the final release, which comes before post-releases, e.g.
+// `1.0.dev1 < 1.0a1 < 1.0 < 1.0.post1`. A local version comes after the
+// same version without one.
+func (v *PEP440Version) Compare(other *PEP440Version) int {
+	if result := compareInts(v.epoch, other.epoch); result != 0 {
+		return result
+	}
+	if result := comparePEP440Release(v.release, other.release); result != 0 {
+		return result
+	}
+	if result := v.comparePublicSuffix(other); result != 0 {
+		return result
+	}
+	return comparePEP440Local(v.local, other.local)
+}
+
+// comparePublicSuffix compares the pre-release, post-release and
+// development release segments.
+func (v *PEP440Version) comparePublicSuffix(other *PEP440Version) int {
+	if result := compareInts(v.preRank(), other.preRank()); result != 0 {
+		return result
+	}
+	if v.preLabel != "" {
+		if result := strings.Compare(v.preLabel, other.preLabel); result != 0 {
+			return result
+		}
+		if result := compareInts(v.preNumber, other.preNumber); result != 0 {
+			return result
+		}
+	}
+
+	switch {
+	case v.hasPost == true && other.hasPost == false:
+		return 1
+	case v.hasPost == false && other.hasPost == true:
+		return -1
+	case v.hasPost == true:
+		if result := compareInts(v.postNumber, other.postNumber); result != 0 {
+			return result
+		}
+	}
+
+	switch {
+	case v.hasDev == true && other.hasDev == false:
+		return -1
+	case v.hasDev == false && other.hasDev == true:
+		return 1
+	default:
+		return compareInts(v.devNumber, other.devNumber)
+	}
+}
+
+// preRank orders a version relative to the pre-releases of its release: a
+// development release without a pre-release or post-release comes before
+// every pre-release, and a version without a pre-release after them.
+func (v *PEP440Version) preRank() int {
+	switch {
+	case v.preLabel != "":
+		return 0
+	case v.hasDev == true && v.hasPost == false:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// IsPrerelease determines if the version is a pre-release or a development
+// release, e.g. `1.0rc1` or `1.0.dev3`.
+func (v *PEP440Version) IsPrerelease() bool {
+	return v.preLabel != "" || v.hasDev == true
+}
+
+// withoutLocal returns a copy of the version without its local version
+// label.
+func (v *PEP440Version) withoutLocal() *PEP440Version {
+	public := *v
+	public.local = nil
+	return &public
+}
+
+// base returns the epoch and release segment of the version, e.g. `1.0` for
+// `1.0rc1.post2+local`.
+func (v *PEP440Version) base() *PEP440Version {
+	return &PEP440Version{epoch: v.epoch, release: v.release}
+}
+
+// comparePEP440Release compares two release segments as if the shorter one
+// was padded with zeros.
+func comparePEP440Release(a []int, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i += 1 {
+		aComponent, bComponent := 0, 0
+		if i < len(a) {
+			aComponent = a[i]
+		}
+		if i < len(b) {
+			bComponent = b[i]
+		}
+		if result := compareInts(aComponent, bComponent); result != 0 {
+			return result
+		}
+	}
+	return 0
+}
+
+// comparePEP440Local compares two local version labels. Numeric segments
+// are compared numerically, and come after alphanumeric segments, which are
+// compared lexically. A version without a local label comes first.
+func comparePEP440Local(a []string, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i += 1 {
+		aNum, aErr := strconv.ParseUint(a[i], 10, 64)
+		bNum, bErr := strconv.ParseUint(b[i], 10, 64)
+		switch {
+		case aErr == nil && bErr == nil:
+			if aNum != bNum {
+				if aNum > bNum {
+					return 1
+				}
+				return -1
+			}
+		case aErr == nil:
+			return 1
+		case bErr == nil:
+			return -1
+		default:
+			if result := strings.Compare(a[i], b[i]); result != 0 {
+				return result
+			}
+		}
+	}
+	return compareInts(len(a), len(b))
+}
+
+// Semver converts the version to a semver [Version], e.g. `2.0.0rc1` to
+// `2.0.0-rc.1`. Not every PEP 440 version can be represented, so the
+// returned descriptions report what did not survive the conversion. The
+// conversion is lossless when none are returned:
+//
+//   - the epoch is dropped
+//   - release components after the third are dropped, unless they are zero
+//   - a development release of a pre-release or post-release is dropped
+//   - post-releases and local versions are kept as build metadata, which
+//     does not factor into precedence
+//
+// A development release of a final release is converted to a pre-release
+// that comes before every other pre-release, e.g. `1.0.dev3` to
+// `1.0.0-0.dev.3`.
+func (v *PEP440Version) Semver() (*Version, []string) {
+	losses := make([]string, 0)
+	if v.epoch != 0 {
+		losses = append(losses, fmt.Sprintf("epoch `%d!` is dropped", v.epoch))
+	}
+
+	components := make([]int, 3)
+	copy(components, v.release)
+	for _, component := range v.release[min(3, len(v.release)):] {
+		if component != 0 {
+			losses = append(losses, fmt.Sprintf("release `%s` is truncated to `%d.%d.%d`", v.base().public(), components[0], components[1], components[2]))
+			break
+		}
+	}
+
+	pre := ""
+	switch {
+	case v.preLabel != "":
+		pre = fmt.Sprintf("%s.%d", v.preLabel, v.preNumber)
+		if v.hasDev == true {
+			losses = append(losses, fmt.Sprintf("development release `.dev%d` is dropped", v.devNumber))
+		}
+	case v.hasDev == true && v.hasPost == true:
+		losses = append(losses, fmt.Sprintf("development release `.dev%d` is dropped", v.devNumber))
+	case v.hasDev == true:
+		pre = fmt.Sprintf("0.dev.%d", v.devNumber)
+	}
+
+	build := make([]string, 0)
+	if v.hasPost == true {
+		build = append(build, "post", strconv.Itoa(v.postNumber))
+		losses = append(losses, fmt.Sprintf("post-release `.post%d` is kept as build metadata", v.postNumber))
+	}
+	if len(v.local) > 0 {
+		build = append(build, v.local...)
+		losses = append(losses, fmt.Sprintf("local version `+%s` is kept as build metadata", strings.Join(v.local, ".")))
+	}
+
+	result := newVersion(components[0], components[1], components[2], pre)
+	result.build = strings.Join(build, ".")
+	return result, losses
+}
+
+// PEP440Specifier is a set of PEP 440 version specifiers, e.g.
+// `~=2.2, !=2.3.1`. A version satisfies the set when it satisfies every
+// clause of it.
+type PEP440Specifier struct {
+	clauses []pep440Clause
+}
+
+// pep440Clause is a single version specifier, e.g. `==1.4.*`.
+type pep440Clause struct {
+	operator string
+	version  *PEP440Version
+	// wildcard indicates a prefix match, e.g. `==1.4.*`.
+	wildcard bool
+	// arbitrary is the version string of an `===` clause, which does not
+	// have to be a valid version.
+	arbitrary string
+}
+
+var pep440ClausePattern = regexp.MustCompile(`^(~=|===|==|!=|<=|>=|<|>)\s*(\S+)$`)
+
+// PEP440SpecifierFromString parses a comma separated list of PEP 440
+// version specifiers, e.g. `>=1.0, <2.0`. The supported operators are `~=`,
+// `==`, `!=`, `<=`, `>=`, `<`, `>` and `===`. Empty clauses are ignored, so
+// an empty string is satisfied by every version that is not a pre-release.
+func PEP440SpecifierFromString(input string) (*PEP440Specifier, error) {
+	specifier := &PEP440Specifier{clauses: make([]pep440Clause, 0)}
+
+	for _, piece := range strings.Split(input, ",") {
+		piece = strings.TrimSpace(piece)
+		if piece == "" {
+			continue
+		}
+		clause, err := parsePEP440Clause(piece)
+		if err != nil {
+			return nil, err
+		}
+		specifier.clauses = append(specifier.clauses, clause)
+	}
+	return specifier, nil
+}
+
+func parsePEP440Clause(input string) (pep440Clause, error) {
+	matches := pep440ClausePattern.FindStringSubmatch(input)
+	if matches == nil {
+		return pep440Clause{}, fmt.Errorf("%w: `%s`", ErrPEP440Specifier, input)
+	}
+
+	clause := pep440Clause{operator: matches[1]}
+	text := matches[2]
+	if clause.operator == "===" {
+		clause.arbitrary = text
+		// The version is only used to decide if the clause opts in to
+		// pre-releases.
+		clause.version, _ = PEP440VersionFromString(text)
+		return clause, nil
+	}
+
+	if strings.HasSuffix(text, ".*") == true {
+		if clause.operator != "==" && clause.operator != "!=" {
+			return clause, fmt.Errorf("%w: `.*` can not be used with `%s` in `%s`", ErrPEP440Specifier, clause.operator, input)
+		}
+		clause.wildcard = true
+		text = strings.TrimSuffix(text, ".*")
+	}
+
+	v, err := PEP440VersionFromString(text)
+	if err != nil {
+		return clause, fmt.Errorf("%w: %w", ErrPEP440Specifier, err)
+	}
+	clause.version = v
+
+	switch {
+	case clause.wildcard == true && (v.preLabel != "" || v.hasPost == true || v.hasDev == true || len(v.local) > 0):
+		return clause, fmt.Errorf("%w: `.*` must follow the release segment in `%s`", ErrPEP440Specifier, input)
+	case len(v.local) > 0 && clause.operator != "==" && clause.operator != "!=":
+		return clause, fmt.Errorf("%w: a local version can not be used with `%s` in `%s`", ErrPEP440Specifier, clause.operator, input)
+	case clause.operator == "~=" && len(v.release) < 2:
+		return clause, fmt.Errorf("%w: `~=` requires at least two release components in `%s`", ErrPEP440Specifier, input)
+	}
+	return clause, nil
+}
+
+// String renders the specifiers in normalized form, separated by commas,
+// e.g. `~=2.2, ==1.4.*`.
+func (s *PEP440Specifier) String() string {
+	parts := make([]string, 0, len(s.clauses))
+	for _, clause := range s.clauses {
+		parts = append(parts, clause.String())
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (c pep440Clause) String() string {
+	switch {
+	case c.operator == "===":
+		return c.operator + c.arbitrary
+	case c.wildcard == true:
+		return c.operator + c.version.String() + ".*"
+	default:
+		return c.operator + c.version.String()
+	}
+}
+
+// Satisfies determines if the version is covered by the provided
+// [PEP440Specifier].
+//
+// Like pip, a pre-release or development release only satisfies the
+// specifier when a clause of it, other than `!=`, names a pre-release or
+// development release, e.g. `>=1.0rc1` is satisfied by `2.0b1`, while
+// `>=1.0` is not.
+func (v *PEP440Version) Satisfies(s *PEP440Specifier) bool {
+	return v.SatisfiesWithOptions(s, Options{})
+}
+
+// SatisfiesWithOptions is the same as [PEP440Version.Satisfies] with the
+// evaluation altered by the provided [Options]. Only
+// [Options.IncludePrerelease] applies to PEP 440 specifiers.
+func (v *PEP440Version) SatisfiesWithOptions(s *PEP440Specifier, opts Options) bool {
+	if v.IsPrerelease() == true && opts.IncludePrerelease == false && s.allowsPrereleases() == false {
+		return false
+	}
+	for _, clause := range s.clauses {
+		if clause.contains(v) == false {
+			return false
+		}
+	}
+	return true
+}
+
+// Check determines if the version satisfies the specifier, as described by
+// [PEP440Version.Satisfies]. It makes a specifier a [Constraint] of PEP 440
+// versions.
+func (s *PEP440Specifier) Check(v *PEP440Version) bool {
+	return v.Satisfies(s)
+}
+
+// allowsPrereleases determines if any clause of the specifier opts in to
+// pre-releases.
+func (s *PEP440Specifier) allowsPrereleases() bool {
+	for _, clause := range s.clauses {
+		switch clause.operator {
+		case "==", ">=", "<=", "~=", "===", ">", "<":
+			if clause.version != nil && clause.version.IsPrerelease() == true {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c pep440Clause) contains(v *PEP440Version) bool {
+	spec := c.version
+	switch c.operator {
+	case "===":
+		return strings.EqualFold(v.String(), c.arbitrary)
+	case "==":
+		return c.equals(v)
+	case "!=":
+		return c.equals(v) == false
+	case "~=":
+		prefix := pep440Clause{
+			operator: "==",
+			version:  &PEP440Version{epoch: spec.epoch, release: spec.release[:len(spec.release)-1]},
+			wildcard: true,
+		}
+		return v.withoutLocal().Compare(spec) >= 0 && prefix.equals(v)
+	case "<=":
+		return v.withoutLocal().Compare(spec) <= 0
+	case ">=":
+		return v.withoutLocal().Compare(spec) >= 0
+	case "<":
+		if v.Compare(spec) >= 0 {
+			return false
+		}
+		// `<3.1` is not satisfied by the pre-releases of `3.1`.
+		return spec.IsPrerelease() == true || v.IsPrerelease() == false || v.base().Compare(spec.base()) != 0
+	default:
+		if v.Compare(spec) <= 0 {
+			return false
+		}
+		// `>3.1` is not satisfied by the post-releases, or local versions, of
+		// `3.1`.
+		if v.base().Compare(spec.base()) != 0 {
+			return true
+		}
+		if spec.hasPost == false && v.hasPost == true {
+			return false
+		}
+		return len(v.local) == 0
+	}
+}
+
+// equals evaluates an `==` clause, including prefix matches.
+func (c pep440Clause) equals(v *PEP440Version) bool {
+	spec := c.version
+	if c.wildcard == true {
+		if v.epoch != spec.epoch {
+			return false
+		}
+		for i, component := range spec.release {
+			candidate := 0
+			if i < len(v.release) {
+				candidate = v.release[i]
+			}
+			if candidate != component {
+				return false
+			}
+		}
+		return true
+	}
+
+	if len(spec.local) == 0 {
+		return v.withoutLocal().Compare(spec) == 0
+	}
+	return v.Compare(spec) == 0
+}
@@ -0,0 +1,195 @@
+package semver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPEP440VersionFromString(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{input: "1.0", expected: "1.0"},
+		{input: "v1.0", expected: "1.0"},
+		{input: " 1.0.0 ", expected: "1.0.0"},
+		{input: "01.02", expected: "1.2"},
+		{input: "1!2.0", expected: "1!2.0"},
+		{input: "2.0.0rc1", expected: "2.0.0rc1"},
+		{input: "1.0-alpha.1", expected: "1.0a1"},
+		{input: "1.0c2", expected: "1.0rc2"},
+		{input: "1.0.preview", expected: "1.0rc0"},
+		{input: "1.0.post2", expected: "1.0.post2"},
+		{input: "1.0-2", expected: "1.0.post2"},
+		{input: "1.0rev3", expected: "1.0.post3"},
+		{input: "1.0.POST", expected: "1.0.post0"},
+		{input: "1.0.dev3", expected: "1.0.dev3"},
+		{input: "1.0_dev_5", expected: "1.0.dev5"},
+		{input: "1.0a1.post2.dev3", expected: "1.0a1.post2.dev3"},
+		{input: "1.0+Ubuntu-1", expected: "1.0+ubuntu.1"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			v, err := PEP440VersionFromString(testCase.input)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, v.String())
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		for _, input := range []string{"", "1.0.", "a1.0", "1.0-", "1..0", "1.0+", "1.0+a..b", "1.0abc"} {
+			v, err := PEP440VersionFromString(input)
+			assert.Nil(t, v)
+			assert.ErrorIs(t, err, ErrPEP440Version, input)
+		}
+	})
+}
+
+func TestPEP440Version_Compare(t *testing.T) {
+	// Every version is lower than the versions after it.
+	ordered := []string{
+		"0.9", "1.0.dev0", "1.0.dev3", "1.0a1.dev1", "1.0a1", "1.0a2", "1.0b1", "1.0rc1", "1.0c2", "1.0",
+		"1.0+abc.5", "1.0+ubuntu.2", "1.0+ubuntu1", "1.0+1", "1.0.post1.dev1", "1.0.post1", "1.0.post2",
+		"1.0.0.0.1", "1.0.1a1", "1.0.1", "1.1", "2.0.0rc1", "2.0", "1!0.5", "1!2.0",
+	}
+	for i := 0; i < len(ordered)-1; i += 1 {
+		a, _ := PEP440VersionFromString(ordered[i])
+		b, _ := PEP440VersionFromString(ordered[i+1])
+		assert.Equal(t, -1, a.Compare(b), ordered[i]+" < "+ordered[i+1])
+		assert.Equal(t, 1, b.Compare(a), ordered[i+1]+" > "+ordered[i])
+	}
+
+	equal := [][]string{
+		{"1.0", "1.0.0"},
+		{"1.0", "1.0.0.0"},
+		{"1.0rc1", "1.0c1"},
+		{"1.0.post0", "1.0-0"},
+		{"1.0+ubuntu.1", "1.0+ubuntu-1"},
+	}
+	for _, pair := range equal {
+		a, _ := PEP440VersionFromString(pair[0])
+		b, _ := PEP440VersionFromString(pair[1])
+		assert.Equal(t, 0, a.Compare(b), pair[0]+" == "+pair[1])
+	}
+}
+
+func TestPEP440Version_Satisfies(t *testing.T) {
+	// The expected matches were recorded from the Python `packaging` library,
+	// version 25.0.
+	versions := []string{
+		"0.9", "1.0.dev0", "1.0a1", "1.0rc1", "1.0", "1.0+ubuntu1", "1.0.post1", "1.0.1", "1.4", "1.4.2",
+		"1.4.2+local", "1.5", "1.5.0.post1", "2.0.0rc1", "2.0", "2.2", "2.2.1", "2.3", "3.1.dev0", "1!2.0",
+	}
+	testCases := []struct {
+		specifier string
+		matches   []string
+	}{
+		{specifier: "", matches: []string{"0.9", "1.0", "1.0+ubuntu1", "1.0.post1", "1.0.1", "1.4", "1.4.2", "1.4.2+local", "1.5", "1.5.0.post1", "2.0", "2.2", "2.2.1", "2.3", "1!2.0"}},
+		{specifier: "~=2.2", matches: []string{"2.2", "2.2.1", "2.3"}},
+		{specifier: "~=2.2.0", matches: []string{"2.2", "2.2.1"}},
+		{specifier: "~=1.4.5a4", matches: []string{}},
+		{specifier: "==1.4.*", matches: []string{"1.4", "1.4.2", "1.4.2+local"}},
+		{specifier: "!=1.5", matches: []string{"0.9", "1.0", "1.0+ubuntu1", "1.0.post1", "1.0.1", "1.4", "1.4.2", "1.4.2+local", "1.5.0.post1", "2.0", "2.2", "2.2.1", "2.3", "1!2.0"}},
+		{specifier: "==1.0", matches: []string{"1.0", "1.0+ubuntu1"}},
+		{specifier: "==1.0+ubuntu1", matches: []string{"1.0+ubuntu1"}},
+		{specifier: "===1.0", matches: []string{"1.0"}},
+		{specifier: ">=1.0rc1", matches: []string{"1.0rc1", "1.0", "1.0+ubuntu1", "1.0.post1", "1.0.1", "1.4", "1.4.2", "1.4.2+local", "1.5", "1.5.0.post1", "2.0.0rc1", "2.0", "2.2", "2.2.1", "2.3", "3.1.dev0", "1!2.0"}},
+		{specifier: "<1.0", matches: []string{"0.9"}},
+		{specifier: ">1.0", matches: []string{"1.0.1", "1.4", "1.4.2", "1.4.2+local", "1.5", "1.5.0.post1", "2.0", "2.2", "2.2.1", "2.3", "1!2.0"}},
+		{specifier: ">1.0.post0", matches: []string{"1.0.post1", "1.0.1", "1.4", "1.4.2", "1.4.2+local", "1.5", "1.5.0.post1", "2.0", "2.2", "2.2.1", "2.3", "1!2.0"}},
+		{specifier: ">=1.0, !=1.4.2, <2.3", matches: []string{"1.0", "1.0+ubuntu1", "1.0.post1", "1.0.1", "1.4", "1.5", "1.5.0.post1", "2.0", "2.2", "2.2.1"}},
+		{specifier: "<2.0.0rc1", matches: []string{"0.9", "1.0.dev0", "1.0a1", "1.0rc1", "1.0", "1.0+ubuntu1", "1.0.post1", "1.0.1", "1.4", "1.4.2", "1.4.2+local", "1.5", "1.5.0.post1"}},
+		{specifier: "<=2.0.0rc1", matches: []string{"0.9", "1.0.dev0", "1.0a1", "1.0rc1", "1.0", "1.0+ubuntu1", "1.0.post1", "1.0.1", "1.4", "1.4.2", "1.4.2+local", "1.5", "1.5.0.post1", "2.0.0rc1"}},
+		{specifier: ">1.0rc1", matches: []string{"1.0", "1.0.1", "1.4", "1.4.2", "1.4.2+local", "1.5", "1.5.0.post1", "2.0.0rc1", "2.0", "2.2", "2.2.1", "2.3", "3.1.dev0", "1!2.0"}},
+		{specifier: "==1!2.0", matches: []string{"1!2.0"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.specifier, func(t *testing.T) {
+			s, err := PEP440SpecifierFromString(testCase.specifier)
+			assert.Nil(t, err)
+
+			matches := make([]string, 0)
+			for _, version := range versions {
+				v, _ := PEP440VersionFromString(version)
+				if v.Satisfies(s) == true {
+					matches = append(matches, version)
+				}
+			}
+			assert.Equal(t, testCase.matches, matches)
+		})
+	}
+
+	t.Run("include pre-releases", func(t *testing.T) {
+		s, _ := PEP440SpecifierFromString(">=1.0")
+		v, _ := PEP440VersionFromString("2.0.0rc1")
+		assert.Equal(t, false, v.Satisfies(s))
+		assert.Equal(t, true, v.SatisfiesWithOptions(s, Options{IncludePrerelease: true}))
+	})
+}
+
+func TestPEP440SpecifierFromString(t *testing.T) {
+	s, err := PEP440SpecifierFromString(" ~= 2.2 ,==1.4.*,, !=1.0+Ubuntu-1, ===anything-goes ")
+	assert.Nil(t, err)
+	assert.Equal(t, "~=2.2, ==1.4.*, !=1.0+ubuntu.1, ===anything-goes", s.String())
+
+	t.Run("errors", func(t *testing.T) {
+		inputs := []string{
+			"1.0", "=1.0", "==", "~=1", "~=1.0.*", ">=1.0.*", "==1.0a1.*", "==1.0.dev1.*", "==1.0+local.*",
+			">=1.0+local", "~=1.0+local", ">=1.0abc",
+		}
+		for _, input := range inputs {
+			s, err := PEP440SpecifierFromString(input)
+			assert.Nil(t, s)
+			assert.ErrorIs(t, err, ErrPEP440Specifier, input)
+		}
+	})
+}
+
+func TestPEP440Version_Semver(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+		losses   []string
+	}{
+		{input: "1", expected: "1.0.0", losses: []string{}},
+		{input: "2.0.0rc1", expected: "2.0.0-rc.1", losses: []string{}},
+		{input: "1.2.3.0", expected: "1.2.3", losses: []string{}},
+		{input: "1.0.dev3", expected: "1.0.0-0.dev.3", losses: []string{}},
+		{input: "1!2.0", expected: "2.0.0", losses: []string{"epoch `1!` is dropped"}},
+		{input: "1.2.3.4", expected: "1.2.3", losses: []string{"release `1.2.3.4` is truncated to `1.2.3`"}},
+		{input: "1.0a1.dev2", expected: "1.0.0-a.1", losses: []string{"development release `.dev2` is dropped"}},
+		{input: "1.0.post2", expected: "1.0.0+post.2", losses: []string{"post-release `.post2` is kept as build metadata"}},
+		{input: "1.0+ubuntu1", expected: "1.0.0+ubuntu1", losses: []string{"local version `+ubuntu1` is kept as build metadata"}},
+		{
+			input:    "1.0.post1.dev2+ubuntu.1",
+			expected: "1.0.0+post.1.ubuntu.1",
+			losses: []string{
+				"development release `.dev2` is dropped",
+				"post-release `.post1` is kept as build metadata",
+				"local version `+ubuntu.1` is kept as build metadata",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			v, _ := PEP440VersionFromString(testCase.input)
+			converted, losses := v.Semver()
+			assert.Equal(t, testCase.expected, converted.String())
+			assert.Equal(t, testCase.losses, losses)
+		})
+	}
+
+	t.Run("preserves the order of lossless conversions", func(t *testing.T) {
+		ordered := []string{"1.0.dev1", "1.0.dev2", "1.0a1", "1.0b1", "1.0rc1", "1.0", "1.0.1"}
+		for i := 0; i < len(ordered)-1; i += 1 {
+			a, _ := PEP440VersionFromString(ordered[i])
+			b, _ := PEP440VersionFromString(ordered[i+1])
+			aSemver, _ := a.Semver()
+			bSemver, _ := b.Semver()
+			assert.Equal(t, true, aSemver.Less(bSemver), ordered[i])
+		}
+	})
+}
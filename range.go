@@ -8,6 +8,9 @@ import (
 )
 
 var ErrRangeAlpha = errors.New("encountered alpha character in range string")
+var ErrRangeUnsatisfiable = errors.New("range can not be satisfied by any version")
+var ErrRangeSyntax = errors.New("invalid range syntax")
+var ErrRangeOperator = errors.New("encountered unknown operator in range string")
 
 type RangeOperator int
 
@@ -18,6 +21,7 @@ const (
 	OperatorGreaterThan
 	OperatorGreaterThanEqual
 	OperatorUnknown
+	OperatorNotEqual
 )
 
 // TODO: support "advanced range syntax" (e.g. ^1.0.0)
@@ -33,11 +37,24 @@ func (r RangeOperator) String() string {
 		return ">"
 	case OperatorGreaterThanEqual:
 		return ">="
+	case OperatorNotEqual:
+		return "!="
 	default:
 		return "<>"
 	}
 }
 
+// isValid determines if the operator is one that a comparator can be made
+// of, i.e. it is neither [OperatorUnknown] nor out of range.
+func (r RangeOperator) isValid() bool {
+	switch r {
+	case OperatorEqual, OperatorLessThan, OperatorLessThanEqual, OperatorGreaterThan, OperatorGreaterThanEqual, OperatorNotEqual:
+		return true
+	default:
+		return false
+	}
+}
+
 func RangeOperatorFromBytes(input []byte) RangeOperator {
 	switch {
 	case bytes.Equal(input, []byte("=")):
@@ -50,6 +67,8 @@ func RangeOperatorFromBytes(input []byte) RangeOperator {
 		return OperatorGreaterThan
 	case bytes.Equal(input, []byte(">=")):
 		return OperatorGreaterThanEqual
+	case bytes.Equal(input, []byte("!=")):
+		return OperatorNotEqual
 	default:
 		return OperatorUnknown
 	}
@@ -77,212 +96,147 @@ func newComparator() *Comparator {
 	}
 }
 
+// Operator returns the operator of the comparator.
+func (c *Comparator) Operator() RangeOperator {
+	return c.operator
+}
+
+// Version returns the version the comparator compares against.
+func (c *Comparator) Version() *Version {
+	return c.version
+}
+
 func (c *Comparator) String() string {
 	builder := strings.Builder{}
 	builder.WriteString(c.operator.String())
-	builder.WriteString(c.version.String())
+	if c.excludesSpan() == true {
+		builder.WriteString(versionSyntax(c.version))
+	} else {
+		builder.WriteString(c.version.String())
+	}
 	return builder.String()
 }
 
-type ComparatorSet struct {
-	one *Comparator
-	two *Comparator
+// excludesSpan determines if the comparator is a `!=` with a partial
+// version, e.g. `!=1.2`, which excludes a span of versions rather than a
+// single version. npm ranges and Masterminds constraints produce such a
+// comparator.
+func (c *Comparator) excludesSpan() bool {
+	return c.operator == OperatorNotEqual && c.version.patchParsed == false
 }
 
-type Range struct {
-	comparators []ComparatorSet
-}
+type ComparatorSet struct {
+	comparators []*Comparator
 
-func RangeFromString(input string) (*Range, error) {
-	return RangeFromBytes([]byte(input))
+	// prerelease, when not nil, replaces the pre-release rule described by
+	// [Version.Satisfies] for the set.
+	prerelease prereleasePolicy
 }
 
-func RangeFromBytes(input []byte) (*Range, error) {
-	if bytes.Equal(input, []byte("")) == true {
-		// An empty string is a special case range that maps
-		// to ">=0.0.0".
-		c := &Comparator{
-			operator: OperatorGreaterThanEqual,
-			version: &Version{
-				majorParsed: true,
-				minorParsed: true,
-				patchParsed: true,
-			},
-			operatorBytes: nil,
-			versionBytes:  nil,
-		}
-		set := ComparatorSet{one: c}
-		return &Range{comparators: []ComparatorSet{set}}, nil
-	}
-
-	setsToParse := bytes.Split(input, []byte("||"))
-
-	comparators := make([]ComparatorSet, 0)
-	for _, set := range setsToParse {
-		set = bytes.TrimSpace(set)
-
-		range1, range2, found := bytes.Cut(set, []byte(" - "))
-		if found == true {
-			// We have a hyphen range, e.g. `1.0.0 - 2.0.0`.
-			comparatorSet, err := parseHyphenRange(range1, range2)
-			if err != nil {
-				return nil, err
-			}
-			comparators = append(comparators, comparatorSet)
-			continue
-		}
+// prereleasePolicy decides which pre-release versions may satisfy a
+// comparator set, e.g. according to Composer stability flags.
+type prereleasePolicy interface {
+	// String describes the policy, e.g. `@beta`.
+	String() string
+	// allows determines if the pre-release version may satisfy the set.
+	allows(v *Version) bool
+	// lowest returns the lowest pre-releases of the tuple that the policy
+	// could allow, in order to find the lowest version satisfying a set.
+	lowest(tuple *Version) []*Version
+}
 
-		if bytes.HasPrefix(set, []byte("~")) == true {
-			comparatorSet, err := parseTildeRange(set[1:])
-			if err != nil {
-				return nil, err
-			}
-			comparators = append(comparators, comparatorSet)
-			continue
-		}
+// namedPrereleasePolicy is the [prereleasePolicy] of the dialects that
+// allow pre-releases within the bounds of a comparator set when any
+// constraint of the set names a pre-release, regardless of its
+// `[major, minor, patch]` tuple, and none otherwise.
+type namedPrereleasePolicy struct {
+	// namedBy is the first constraint of the set that names a pre-release.
+	// It is empty when no constraint of the set names a pre-release.
+	namedBy string
+}
 
-		if bytes.HasPrefix(set, []byte("^")) == true {
-			comparatorSet, err := parseCaretRange(set[1:])
-			if err != nil {
-				return nil, err
-			}
-			comparators = append(comparators, comparatorSet)
-			continue
-		}
+func (p namedPrereleasePolicy) String() string {
+	return p.namedBy
+}
 
-		range1, range2, found = bytes.Cut(set, []byte(" "))
-		if found == true {
-			// We have a basic range separated by a space, e.g. `1.0.0 2.0.0`.
-			comparatorSet, err := parseBasicRange(range1, range2)
-			if err != nil {
-				return nil, err
-			}
-			comparators = append(comparators, comparatorSet)
-			continue
-		}
+func (p namedPrereleasePolicy) allows(v *Version) bool {
+	return p.namedBy != ""
+}
 
-		// We have a simple range, e.g. `=1.0.0`, or the special "any version"
-		// string (`*`).
-		one, err := parseComparator(set)
-		if err != nil {
-			return nil, err
-		}
-		if one.version.partial == true && one.version.majorParsed == false {
-			// The "any version" x-range case.
-			one.operator = OperatorGreaterThanEqual
-			comparators = append(comparators, ComparatorSet{one: one})
-		} else if one.version.partial {
-			if one.parsedOperator == false {
-				one.operator = OperatorGreaterThanEqual
-			}
-			two := buildSecondComparatorFromPartial(one)
-			comparators = append(comparators, ComparatorSet{one, two})
-		} else {
-			comparators = append(comparators, ComparatorSet{one: one})
-		}
+func (p namedPrereleasePolicy) lowest(tuple *Version) []*Version {
+	if p.namedBy == "" {
+		return nil
 	}
+	return []*Version{newVersion(tuple.major, tuple.minor, tuple.patch, "0")}
+}
 
-	return &Range{comparators: comparators}, nil
+func newComparatorSet(comparators ...*Comparator) ComparatorSet {
+	return ComparatorSet{comparators: comparators}
 }
 
-func parseHyphenRange(r1 []byte, r2 []byte) (ComparatorSet, error) {
-	c1, err := parseComparator(bytes.TrimSpace(r1))
-	if err != nil {
-		return ComparatorSet{}, nil
-	}
+// Comparators returns the primitive comparators of the set. A version must
+// satisfy every one of them in order to satisfy the set.
+func (s ComparatorSet) Comparators() []*Comparator {
+	return s.comparators
+}
 
-	c2, err := parseComparator(bytes.TrimSpace(r2))
-	if err != nil {
-		return ComparatorSet{}, nil
+func (s ComparatorSet) String() string {
+	parts := make([]string, 0, len(s.comparators))
+	for _, c := range s.comparators {
+		parts = append(parts, c.String())
 	}
+	return strings.Join(parts, " ")
+}
 
-	// We don't need to consider `.parsedOperator` here because the hyphen
-	// range doesn't use them. It provides a set of cases that dictate which
-	// operators to apply.
-	c1.operator = OperatorGreaterThanEqual
-	if c2.version.patchParsed == true {
-		c2.operator = OperatorLessThanEqual
-	} else if c2.version.minorParsed == true {
-		c2.version.minor += 1
-		c2.operator = OperatorLessThan
-	} else {
-		c2.version.major += 1
-		c2.operator = OperatorLessThan
-	}
+type Range struct {
+	comparators []ComparatorSet
 
-	return ComparatorSet{c1, c2}, nil
-}
+	// raw holds the original text of every comparator set, trimmed of
+	// surrounding whitespace. It is nil for ranges that were not parsed
+	// from text, e.g. the results of [Range.Union].
+	raw []string
 
-func parseTildeRange(r1 []byte) (ComparatorSet, error) {
-	c1, err := parseComparator(r1)
-	if err != nil {
-		return ComparatorSet{}, err
-	}
-	c1.operator = OperatorGreaterThanEqual
+	// source is the complete text the range was parsed from, when joining
+	// raw with `||` does not reproduce it, e.g. for interval notation.
+	source string
 
-	c2 := newComparator()
-	c2.operator = OperatorLessThan
-	c2.version = &Version{
-		major: c1.version.major,
-		minor: c1.version.minor,
-	}
-	if c1.version.patchParsed == true {
-		c2.version.minor += 1
-	} else if c1.version.minorParsed == true {
-		c2.version.minor += 1
-	} else if c1.version.majorParsed == true {
-		c2.version.major += 1
-	}
+	// options are the [Options] the range was parsed with.
+	options Options
+}
 
-	return ComparatorSet{c1, c2}, nil
+// Sets returns the comparator sets, i.e. the sets separated by `||`, of the
+// desugared range.
+func (r *Range) Sets() []ComparatorSet {
+	return r.comparators
 }
 
-func parseCaretRange(r1 []byte) (ComparatorSet, error) {
-	c1, err := parseComparator(r1)
-	if err != nil {
-		return ComparatorSet{}, err
-	}
-	c1.operator = OperatorGreaterThanEqual
-
-	c2 := newComparator()
-	c2.operator = OperatorLessThan
-	c2.version = &Version{
-		major: c1.version.major,
-		minor: c1.version.minor,
-		patch: c1.version.patch,
-	}
-	if c1.version.major > 0 {
-		c2.version.major += 1
-		c2.version.minor = 0
-		c2.version.patch = 0
-	} else if c1.version.minor > 0 {
-		c2.version.minor += 1
-		c2.version.patch = 0
-	} else if c2.version.patch > 0 {
-		c2.version.patch += 1
-	} else if c1.version.minorParsed == true && c1.version.partial == true {
-		// (^0.0.x|^0.0) => >=0.0.0 <0.1.0
-		c2.version.minor += 1
-	} else if c1.version.majorParsed == true && c1.version.partial == true {
-		// ^0.x => >=0.0.0 <1.0.0
-		c2.version.major += 1
-	}
+func RangeFromString(input string) (*Range, error) {
+	return RangeFromBytes([]byte(input))
+}
 
-	return ComparatorSet{c1, c2}, nil
+func RangeFromBytes(input []byte) (*Range, error) {
+	return RangeFromBytesWithOptions(input, Options{})
 }
 
-func parseBasicRange(r1 []byte, r2 []byte) (ComparatorSet, error) {
-	c1, err := parseComparator(bytes.TrimSpace(r1))
-	if err != nil {
-		return ComparatorSet{}, err
-	}
+// RangeFromStringWithOptions is the same as [RangeFromString] with the
+// parsing, and later evaluation, of the range altered by the provided
+// [Options].
+func RangeFromStringWithOptions(input string, opts Options) (*Range, error) {
+	return RangeFromBytesWithOptions([]byte(input), opts)
+}
 
-	c2, err := parseComparator(bytes.TrimSpace(r2))
+// RangeFromBytesWithOptions is the same as [RangeFromBytes] with the
+// parsing, and later evaluation, of the range altered by the provided
+// [Options]. Parsing is performed in two steps: the input is parsed into a
+// [RangeNode] by [ParseAST], which is then desugared into primitive
+// comparators as by [RangeNode.Desugar], keeping the source text of the
+// sets as the [Range.Raw] text.
+func RangeFromBytesWithOptions(input []byte, opts Options) (*Range, error) {
+	root, err := ParseAST(string(input))
 	if err != nil {
-		return ComparatorSet{}, err
+		return nil, err
 	}
-
-	return ComparatorSet{c1, c2}, nil
+	return root.desugar(opts, root.sourceSets())
 }
 
 func parseComparator(r []byte) (*Comparator, error) {
@@ -315,6 +269,9 @@ func parseComparator(r []byte) (*Comparator, error) {
 func finalizeComparator(c *Comparator) error {
 	if len(c.operatorBytes) > 0 {
 		c.operator = RangeOperatorFromBytes(c.operatorBytes)
+		if c.operator == OperatorUnknown {
+			return fmt.Errorf("%w: `%s`", ErrRangeOperator, c.operatorBytes)
+		}
 		c.operatorBytes = make([]byte, 0)
 		c.parsedOperator = true
 	}
@@ -343,15 +300,50 @@ func buildSecondComparatorFromPartial(c1 *Comparator) *Comparator {
 	return c2
 }
 
+// String renders the range according to the [RangeSyntax] selected by the
+// options the range was parsed with. By default the desugared comparators
+// are rendered, e.g. `^1.2.3` is rendered as `>=1.2.3 <2.0.0-0`.
 func (r *Range) String() string {
-	builder := strings.Builder{}
-	for _, set := range r.comparators {
-		builder.WriteString(set.one.String())
-		if set.two != nil {
-			builder.WriteString(fmt.Sprintf(" %s", set.two))
+	if r.options.Syntax == SyntaxOriginal {
+		return r.Raw()
+	}
+	return r.desugaredString()
+}
+
+// Raw returns the text the range was parsed from, with every comparator set
+// trimmed of surrounding whitespace, e.g. `^1.2.3 ||  ~2.0 ` is returned as
+// `^1.2.3 || ~2.0`. Ranges that were not parsed from text, such as the
+// results of [Range.Union], return their desugared comparators instead.
+func (r *Range) Raw() string {
+	if r.raw == nil {
+		return r.desugaredString()
+	}
+	if r.source != "" {
+		return r.source
+	}
+	// An empty set does not add whitespace, e.g. `||` rather than ` || `.
+	tokens := make([]string, 0, len(r.raw)*2)
+	for i, set := range r.raw {
+		if i > 0 {
+			tokens = append(tokens, "||")
 		}
-		builder.WriteString(" || ")
+		if set != "" {
+			tokens = append(tokens, set)
+		}
+	}
+	return strings.Join(tokens, " ")
+}
+
+// Check determines if the version satisfies the range, as described by
+// [Version.Satisfies]. It makes a range a [Constraint] of versions.
+func (r *Range) Check(v *Version) bool {
+	return v.Satisfies(r)
+}
+
+func (r *Range) desugaredString() string {
+	sets := make([]string, 0, len(r.comparators))
+	for _, set := range r.comparators {
+		sets = append(sets, set.String())
 	}
-	s := builder.String()
-	return strings.TrimSuffix(s, " || ")
+	return strings.Join(sets, " || ")
 }
@@ -44,6 +44,22 @@ func TestRange_RangeFromString(t *testing.T) {
 			expected: ">1.0.0 <2.0.0 || >2.0.0 <3.0.0 || <5.0.0 <6.0.0",
 		},
 
+		{
+			title:    "not equal",
+			input:    "^2.0.0 != 2.3.1",
+			expected: ">=2.0.0 <3.0.0-0 !=2.3.1",
+		},
+		{
+			title:    "not equal partial excludes a span",
+			input:    "!=1.2",
+			expected: "!=1.2",
+		},
+		{
+			title:    "not equal any excludes every version",
+			input:    "!=*",
+			expected: "<0.0.0-0",
+		},
+
 		// Hyphen ranges
 		{
 			title:    "hyphen: basic inclusive set",
@@ -107,89 +123,134 @@ func TestRange_RangeFromString(t *testing.T) {
 		{
 			title:    "tilde range: major, minor, patch",
 			input:    "~1.2.3",
-			expected: ">=1.2.3 <1.3.0",
+			expected: ">=1.2.3 <1.3.0-0",
 		},
 		{
 			title:    "tilde range: major and minor",
 			input:    "~1.2",
-			expected: ">=1.2.0 <1.3.0",
+			expected: ">=1.2.0 <1.3.0-0",
 		},
 		{
 			title:    "tilde range: major",
 			input:    "~1",
-			expected: ">=1.0.0 <2.0.0",
+			expected: ">=1.0.0 <2.0.0-0",
 		},
 		{
 			title:    "tilde range: major 0, minor, patch",
 			input:    "~0.2.3",
-			expected: ">=0.2.3 <0.3.0",
+			expected: ">=0.2.3 <0.3.0-0",
 		},
 		{
 			title:    "tilde range: major 0, minor",
 			input:    "~0.2",
-			expected: ">=0.2.0 <0.3.0",
+			expected: ">=0.2.0 <0.3.0-0",
 		},
 		{
 			title:    "tilde range: major 0",
 			input:    "~0",
-			expected: ">=0.0.0 <1.0.0",
+			expected: ">=0.0.0 <1.0.0-0",
 		},
 		{
 			title:    "tilde range: with pre",
 			input:    "~1.2.3-beta.2",
-			expected: ">=1.2.3-beta.2 <1.3.0",
+			expected: ">=1.2.3-beta.2 <1.3.0-0",
+		},
+		{
+			title:    "tilde range: major, minor-x",
+			input:    "~1.2.x",
+			expected: ">=1.2.0 <1.3.0-0",
+		},
+		{
+			title:    "tilde range: build metadata is dropped",
+			input:    "~1.2.3+build.7",
+			expected: ">=1.2.3 <1.3.0-0",
+		},
+		{
+			title:    "tilde range: any version",
+			input:    "~*",
+			expected: ">=0.0.0",
 		},
 
 		// Caret ranges:
 		{
 			title:    "caret range: major, minor, patch",
 			input:    "^1.2.3",
-			expected: ">=1.2.3 <2.0.0",
+			expected: ">=1.2.3 <2.0.0-0",
 		},
 		{
 			title:    "caret range: major 0, minor, patch",
 			input:    "^0.2.3",
-			expected: ">=0.2.3 <0.3.0",
+			expected: ">=0.2.3 <0.3.0-0",
 		},
 		{
 			title:    "caret range: major 0, minor 0, patch",
 			input:    "^0.0.3",
-			expected: ">=0.0.3 <0.0.4",
+			expected: ">=0.0.3 <0.0.4-0",
 		},
 		{
 			title:    "caret range: major, minor, patch, pre-release",
 			input:    "^1.2.3-beta.2",
-			expected: ">=1.2.3-beta.2 <2.0.0",
+			expected: ">=1.2.3-beta.2 <2.0.0-0",
 		},
 		{
 			title:    "caret range: major 0, minor 0, patch, pre-release",
 			input:    "^0.0.3-beta",
-			expected: ">=0.0.3-beta <0.0.4",
+			expected: ">=0.0.3-beta <0.0.4-0",
 		},
 		{
 			title:    "caret range: major, minor, patch-x",
 			input:    "^1.2.x",
-			expected: ">=1.2.0 <2.0.0",
+			expected: ">=1.2.0 <2.0.0-0",
 		},
 		{
 			title:    "caret range: major 0, minor 0, patch-x",
 			input:    "^0.0.x",
-			expected: ">=0.0.0 <0.1.0",
+			expected: ">=0.0.0 <0.1.0-0",
 		},
 		{
 			title:    "caret range: major 0, minor 0",
 			input:    "^0.0",
-			expected: ">=0.0.0 <0.1.0",
+			expected: ">=0.0.0 <0.1.0-0",
 		},
 		{
 			title:    "caret range: major, minor-x",
 			input:    "^1.x",
-			expected: ">=1.0.0 <2.0.0",
+			expected: ">=1.0.0 <2.0.0-0",
 		},
 		{
 			title:    "caret range: major 0, minor-x",
 			input:    "^0.x",
-			expected: ">=0.0.0 <1.0.0",
+			expected: ">=0.0.0 <1.0.0-0",
+		},
+		{
+			title:    "caret range: major 0",
+			input:    "^0",
+			expected: ">=0.0.0 <1.0.0-0",
+		},
+		{
+			title:    "caret range: major 0, minor, patch-x",
+			input:    "^0.1.x",
+			expected: ">=0.1.0 <0.2.0-0",
+		},
+		{
+			title:    "caret range: all zeros",
+			input:    "^0.0.0",
+			expected: ">=0.0.0 <0.0.1-0",
+		},
+		{
+			title:    "caret range: major 0, minor, patch, pre-release",
+			input:    "^0.2.3-beta.1",
+			expected: ">=0.2.3-beta.1 <0.3.0-0",
+		},
+		{
+			title:    "caret range: build metadata is dropped",
+			input:    "^1.2.3-beta.2+build.7",
+			expected: ">=1.2.3-beta.2 <2.0.0-0",
+		},
+		{
+			title:    "caret range: any version",
+			input:    "^*",
+			expected: ">=0.0.0",
 		},
 	}
 
@@ -209,4 +270,70 @@ func TestRange_RangeFromString(t *testing.T) {
 		assert.Equal(t, "encountered alpha character in range string: `>=A.0.1`", err.Error())
 		assert.ErrorIs(t, err, ErrRangeAlpha)
 	})
+
+	t.Run("unknown operator in range string", func(t *testing.T) {
+		for _, input := range []string{"=>1.0.0", "<>1.0.0", "!1.0.0", "^1.0.0 ==2.0.0"} {
+			res, err := RangeFromString(input)
+			assert.Nil(t, res)
+			assert.ErrorIs(t, err, ErrRangeOperator, input)
+		}
+	})
+}
+
+func TestRange_Raw(t *testing.T) {
+	testCases := []struct {
+		title    string
+		input    string
+		expected string
+	}{
+		{
+			title:    "caret",
+			input:    "^1.2.3",
+			expected: "^1.2.3",
+		},
+		{
+			title:    "sets are trimmed",
+			input:    "  ~1.2 ||1.x   ||  >= 2.0.0 <3 ",
+			expected: "~1.2 || 1.x || >= 2.0.0 <3",
+		},
+		{
+			title:    "empty string",
+			input:    "",
+			expected: "",
+		},
+		{
+			title:    "empty sets",
+			input:    " || ",
+			expected: "||",
+		},
+		{
+			title:    "empty sets around a set",
+			input:    "|| 1.x ||  ||",
+			expected: "|| 1.x || ||",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			rng, err := RangeFromString(testCase.input)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, rng.Raw())
+		})
+	}
+
+	t.Run("derived ranges fall back to comparators", func(t *testing.T) {
+		a, _ := RangeFromString("^1.2.3")
+		b, _ := RangeFromString("^2.0.0")
+		assert.Equal(t, ">=1.2.3 <2.0.0-0 || >=2.0.0 <3.0.0-0", a.Union(b).Raw())
+	})
+
+	t.Run("syntax option selects the rendering", func(t *testing.T) {
+		rng, err := RangeFromStringWithOptions("^1.2.3 || 2.x", Options{Syntax: SyntaxOriginal})
+		assert.Nil(t, err)
+		assert.Equal(t, "^1.2.3 || 2.x", rng.String())
+
+		rng, err = RangeFromStringWithOptions("^1.2.3 || 2.x", Options{Syntax: SyntaxDesugared})
+		assert.Nil(t, err)
+		assert.Equal(t, ">=1.2.3 <2.0.0-0 || >=2.0.0 <3.0.0", rng.String())
+	})
 }
@@ -0,0 +1,275 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrRPMVersion    = errors.New("invalid RPM version")
+	ErrRPMConstraint = errors.New("invalid RPM constraint")
+)
+
+// RPMVersion is an RPM package version of the form `[epoch:]version[-release]`,
+// e.g. `2:1.0.3-4.el9`. Versions are ordered the way RPM orders them, see
+// [RPMVersion.Compare].
+//
+// See https://rpm-software-management.github.io/rpm/manual/dependencies.html#versioning
+type RPMVersion struct {
+	epoch   int
+	version string
+	// release is empty when the version does not have a release.
+	release string
+}
+
+var rpmVersionPattern = regexp.MustCompile(`^(?:(\d+):)?([0-9A-Za-z._+~^]+)(?:-([0-9A-Za-z._+~^]+))?$`)
+
+// RPMVersionFromString parses an RPM version, e.g. `1.0~rc1`, `1.0^post1`
+// or `2:1.0.3-4.el9`. The epoch is the number before the colon, and the
+// release is the text after the hyphen. The version and release may only
+// contain alphanumerics and the characters `._+~^`.
+func RPMVersionFromString(input string) (*RPMVersion, error) {
+	trimmed := strings.TrimSpace(input)
+	matches := rpmVersionPattern.FindStringSubmatch(trimmed)
+	if matches == nil {
+		return nil, fmt.Errorf("%w: `%s`", ErrRPMVersion, input)
+	}
+
+	v := &RPMVersion{version: matches[2], release: matches[3]}
+	if matches[1] != "" {
+		epoch, err := strconv.Atoi(matches[1])
+		if err != nil {
+			return nil, fmt.Errorf("%w: `%s`: %w", ErrRPMVersion, input, err)
+		}
+		v.epoch = epoch
+	}
+	return v, nil
+}
+
+// String renders the version, e.g. `2:1.0.3-4.el9`. An epoch of zero is
+// omitted.
+func (v *RPMVersion) String() string {
+	builder := strings.Builder{}
+	if v.epoch > 0 {
+		builder.WriteString(strconv.Itoa(v.epoch))
+		builder.WriteString(":")
+	}
+	builder.WriteString(v.version)
+	if v.release != "" {
+		builder.WriteString("-")
+		builder.WriteString(v.release)
+	}
+	return builder.String()
+}
+
+// Compare evaluates the ordinality between two versions the way RPM does.
+// Results:
+//   - `v > other => 1`
+//   - `v < other => -1`
+//   - `v == other => 0`
+//
+// The epochs are compared first, a missing epoch being zero, followed by the
+// versions and the releases, as compared by `rpmvercmp`. A missing release
+// sorts before every release, e.g. `1.0 < 1.0-3 < 1.0-4`, so that versions
+// are totally ordered. [RPMVersion.Satisfies] ignores the release instead.
+//
+// `rpmvercmp` compares versions from left to right by runs of letters, which
+// are compared lexically, and runs of digits, which are compared
+// numerically and are newer than letters. Other characters only separate
+// the runs, except for `~`, which sorts before everything, and `^`, which
+// sorts after the end of a version but before anything else, e.g.
+// `1.0~rc1 < 1.0 < 1.0^post1 < 1.0.1`.
+func (v *RPMVersion) Compare(other *RPMVersion) int {
+	if result := compareInts(v.epoch, other.epoch); result != 0 {
+		return result
+	}
+	if result := rpmvercmp(v.version, other.version); result != 0 {
+		return result
+	}
+	switch {
+	case v.release == "" && other.release == "":
+		return 0
+	case v.release == "":
+		return -1
+	case other.release == "":
+		return 1
+	}
+	return rpmvercmp(v.release, other.release)
+}
+
+// withoutRelease returns the version without its release, e.g. `2:1.0.3` for
+// `2:1.0.3-4.el9`.
+func (v *RPMVersion) withoutRelease() *RPMVersion {
+	return &RPMVersion{epoch: v.epoch, version: v.version}
+}
+
+// isRPMSegmentChar determines if the character is part of a segment, or is a
+// separator that influences the ordering.
+func isRPMSegmentChar(c byte) bool {
+	return isAlphaChar(c) == true || isIntegerChar(c) == true || c == '~' || c == '^'
+}
+
+// rpmvercmp compares two versions, or two releases, following `rpmvercmp`
+// of RPM.
+func rpmvercmp(a string, b string) int {
+	if a == b {
+		return 0
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for i < len(a) && isRPMSegmentChar(a[i]) == false {
+			i += 1
+		}
+		for j < len(b) && isRPMSegmentChar(b[j]) == false {
+			j += 1
+		}
+
+		// A tilde sorts before everything else.
+		aTilde := i < len(a) && a[i] == '~'
+		bTilde := j < len(b) && b[j] == '~'
+		if aTilde == true || bTilde == true {
+			if aTilde == false {
+				return 1
+			}
+			if bTilde == false {
+				return -1
+			}
+			i += 1
+			j += 1
+			continue
+		}
+
+		// A caret sorts before everything else, except for the end of a
+		// version.
+		aCaret := i < len(a) && a[i] == '^'
+		bCaret := j < len(b) && b[j] == '^'
+		if aCaret == true || bCaret == true {
+			if i == len(a) {
+				return -1
+			}
+			if j == len(b) {
+				return 1
+			}
+			if aCaret == false {
+				return 1
+			}
+			if bCaret == false {
+				return -1
+			}
+			i += 1
+			j += 1
+			continue
+		}
+
+		if i == len(a) || j == len(b) {
+			break
+		}
+
+		isSegmentChar := isAlphaChar
+		isNumber := isIntegerChar(a[i])
+		if isNumber == true {
+			isSegmentChar = isIntegerChar
+		}
+		aEnd, bEnd := i, j
+		for aEnd < len(a) && isSegmentChar(a[aEnd]) == true {
+			aEnd += 1
+		}
+		for bEnd < len(b) && isSegmentChar(b[bEnd]) == true {
+			bEnd += 1
+		}
+
+		// Segments of different types: numbers are newer than letters.
+		if bEnd == j {
+			if isNumber == true {
+				return 1
+			}
+			return -1
+		}
+
+		aSegment, bSegment := a[i:aEnd], b[j:bEnd]
+		if isNumber == true {
+			aSegment = strings.TrimLeft(aSegment, "0")
+			bSegment = strings.TrimLeft(bSegment, "0")
+			if result := compareInts(len(aSegment), len(bSegment)); result != 0 {
+				return result
+			}
+		}
+		if result := strings.Compare(aSegment, bSegment); result != 0 {
+			return result
+		}
+		i, j = aEnd, bEnd
+	}
+
+	if i == len(a) && j == len(b) {
+		return 0
+	}
+	if i == len(a) {
+		return -1
+	}
+	return 1
+}
+
+// RPMConstraint is the version relation of an RPM dependency expression,
+// e.g. the `>= 1.2-3` of `Requires: foo >= 1.2-3`.
+type RPMConstraint struct {
+	operator string
+	version  *RPMVersion
+}
+
+var rpmConstraintPattern = regexp.MustCompile(`^(<=|>=|<|>|=)\s*(\S+)$`)
+
+// RPMConstraintFromString parses the version relation of an RPM dependency
+// expression, e.g. `>= 1.2-3`. The supported operators are `<`, `<=`, `=`,
+// `>=` and `>`.
+func RPMConstraintFromString(input string) (*RPMConstraint, error) {
+	trimmed := strings.TrimSpace(input)
+	matches := rpmConstraintPattern.FindStringSubmatch(trimmed)
+	if matches == nil {
+		return nil, fmt.Errorf("%w: `%s`", ErrRPMConstraint, input)
+	}
+	v, err := RPMVersionFromString(matches[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrRPMConstraint, err)
+	}
+	return &RPMConstraint{operator: matches[1], version: v}, nil
+}
+
+// String renders the relation, e.g. `>= 1.2-3`.
+func (c *RPMConstraint) String() string {
+	return c.operator + " " + c.version.String()
+}
+
+// Satisfies determines if the version is covered by the provided
+// [RPMConstraint]. As when RPM matches a dependency, the release is ignored
+// when either side does not have one, e.g. `>= 1.2` is satisfied by
+// `1.2-1`, and `= 1.2` by every release of `1.2`. There is not a
+// pre-release rule: `1.2~rc1` is ordered before `1.2` and satisfies `< 1.2`.
+func (v *RPMVersion) Satisfies(c *RPMConstraint) bool {
+	result := v.Compare(c.version)
+	if v.release == "" || c.version.release == "" {
+		result = v.withoutRelease().Compare(c.version.withoutRelease())
+	}
+	switch c.operator {
+	case "<":
+		return result < 0
+	case "<=":
+		return result <= 0
+	case "=":
+		return result == 0
+	case ">=":
+		return result >= 0
+	default:
+		return result > 0
+	}
+}
+
+// Check determines if the version satisfies the constraint, as described by
+// [RPMVersion.Satisfies]. It makes a constraint a [Constraint] of RPM
+// versions.
+func (c *RPMConstraint) Check(v *RPMVersion) bool {
+	return v.Satisfies(c)
+}
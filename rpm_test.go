@@ -0,0 +1,179 @@
+package semver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRPMVersionFromString(t *testing.T) {
+	testCases := []struct {
+		input    string
+		epoch    int
+		version  string
+		release  string
+		expected string
+	}{
+		{input: "1.0", version: "1.0", expected: "1.0"},
+		{input: " 1.0.3-4.el9 ", version: "1.0.3", release: "4.el9", expected: "1.0.3-4.el9"},
+		{input: "2:1.0.3-4.el9", epoch: 2, version: "1.0.3", release: "4.el9", expected: "2:1.0.3-4.el9"},
+		{input: "0:1.0", version: "1.0", expected: "1.0"},
+		{input: "1.0~rc1", version: "1.0~rc1", expected: "1.0~rc1"},
+		{input: "1.0^post1", version: "1.0^post1", expected: "1.0^post1"},
+		{input: "1.2_3+git", version: "1.2_3+git", expected: "1.2_3+git"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			v, err := RPMVersionFromString(testCase.input)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.epoch, v.epoch)
+			assert.Equal(t, testCase.version, v.version)
+			assert.Equal(t, testCase.release, v.release)
+			assert.Equal(t, testCase.expected, v.String())
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		for _, input := range []string{"", "1.0-", "-1", ":1.0", "a:1.0", "1:", "1.0-1-2", "1.0 1", "1.0/2", "99999999999999999999:1.0"} {
+			v, err := RPMVersionFromString(input)
+			assert.Nil(t, v)
+			assert.ErrorIs(t, err, ErrRPMVersion, input)
+		}
+	})
+}
+
+func TestRPMVersion_Compare(t *testing.T) {
+	// The vectors are from the `rpmvercmp` tests of RPM.
+	testCases := []struct {
+		a        string
+		b        string
+		expected int
+	}{
+		{a: "1.0", b: "1.0", expected: 0},
+		{a: "1.0", b: "2.0", expected: -1},
+		{a: "2.0.1", b: "2.0", expected: 1},
+		{a: "2.0.1a", b: "2.0.1", expected: 1},
+		{a: "5.5p1", b: "5.5p2", expected: -1},
+		{a: "5.5p1", b: "5.5p10", expected: -1},
+		{a: "10xyz", b: "10.1xyz", expected: -1},
+		{a: "xyz10", b: "xyz10.1", expected: -1},
+		{a: "xyz.4", b: "8", expected: -1},
+		{a: "xyz.4", b: "2", expected: -1},
+		{a: "5.5p2", b: "5.6p1", expected: -1},
+		{a: "5.6p1", b: "6.5p1", expected: -1},
+		{a: "6.0.rc1", b: "6.0", expected: 1},
+		{a: "10b2", b: "10a1", expected: 1},
+		{a: "10a2", b: "10b2", expected: -1},
+		{a: "1.0a", b: "1.0aa", expected: -1},
+		{a: "10.0001", b: "10.1", expected: 0},
+		{a: "10.0001", b: "10.0039", expected: -1},
+		{a: "4.999.9", b: "5.0", expected: -1},
+		{a: "20101121", b: "20101122", expected: -1},
+		{a: "2.0", b: "2_0", expected: 0},
+		{a: "a+", b: "a_", expected: 0},
+		{a: "+a", b: "_a", expected: 0},
+		{a: "_+", b: "+_", expected: 0},
+		{a: "+", b: "_", expected: 0},
+		{a: "1.0~rc1", b: "1.0", expected: -1},
+		{a: "1.0~rc1", b: "1.0~rc2", expected: -1},
+		{a: "1.0~rc1~git123", b: "1.0~rc1", expected: -1},
+		{a: "1.0^", b: "1.0", expected: 1},
+		{a: "1.0^git1", b: "1.0", expected: 1},
+		{a: "1.0^git1", b: "1.0^git2", expected: -1},
+		{a: "1.0^git1", b: "1.01", expected: -1},
+		{a: "1.0^20160101", b: "1.0.1", expected: -1},
+		{a: "1.0^20160102", b: "1.0^20160101^git1", expected: 1},
+		{a: "1.0~rc1^git1", b: "1.0~rc1", expected: 1},
+		{a: "1.0^git1", b: "1.0^git1~pre", expected: 1},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.a+" "+testCase.b, func(t *testing.T) {
+			a, err := RPMVersionFromString(testCase.a)
+			assert.Nil(t, err)
+			b, err := RPMVersionFromString(testCase.b)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, a.Compare(b))
+			assert.Equal(t, -testCase.expected, b.Compare(a))
+		})
+	}
+
+	t.Run("epoch and release", func(t *testing.T) {
+		testCases := []struct {
+			a        string
+			b        string
+			expected int
+		}{
+			{a: "1:1.0", b: "2.0", expected: 1},
+			{a: "0:1.0", b: "1.0", expected: 0},
+			{a: "2:1.0.3-4.el9", b: "2:1.0.3-10.el9", expected: -1},
+			{a: "1.0-4.el9", b: "1.0-4.el9_1", expected: -1},
+			{a: "1.0", b: "1.0-3", expected: -1},
+			{a: "1:1.0", b: "1.0-3", expected: 1},
+			{a: "1.0~rc1-5", b: "1.0-1", expected: -1},
+		}
+		for _, testCase := range testCases {
+			a, _ := RPMVersionFromString(testCase.a)
+			b, _ := RPMVersionFromString(testCase.b)
+			assert.Equal(t, testCase.expected, a.Compare(b), testCase.a+" "+testCase.b)
+			assert.Equal(t, -testCase.expected, b.Compare(a), testCase.b+" "+testCase.a)
+		}
+	})
+}
+
+func TestRPMConstraintFromString(t *testing.T) {
+	c, err := RPMConstraintFromString(" >=1.2-3 ")
+	assert.Nil(t, err)
+	assert.Equal(t, ">= 1.2-3", c.String())
+
+	t.Run("errors", func(t *testing.T) {
+		testCases := []struct {
+			input    string
+			expected error
+		}{
+			{input: "", expected: ErrRPMConstraint},
+			{input: "1.2-3", expected: ErrRPMConstraint},
+			{input: "== 1.2", expected: ErrRPMConstraint},
+			{input: "<< 1.2", expected: ErrRPMConstraint},
+			{input: ">= 1.2 < 2.0", expected: ErrRPMConstraint},
+			{input: ">= 1.2-", expected: ErrRPMVersion},
+		}
+		for _, testCase := range testCases {
+			c, err := RPMConstraintFromString(testCase.input)
+			assert.Nil(t, c)
+			assert.ErrorIs(t, err, testCase.expected, testCase.input)
+		}
+	})
+}
+
+func TestRPMVersion_Satisfies(t *testing.T) {
+	testCases := []struct {
+		constraint string
+		version    string
+		expected   bool
+	}{
+		{constraint: ">= 1.2-3", version: "1.2-3", expected: true},
+		{constraint: ">= 1.2-3", version: "1.2-2", expected: false},
+		{constraint: ">= 1.2-3", version: "1:1.0-1", expected: true},
+		{constraint: ">= 1.2", version: "1.2-1", expected: true},
+		{constraint: "= 1.2", version: "1.2-7.el9", expected: true},
+		{constraint: "= 1.2-7.el9", version: "1.2", expected: true},
+		{constraint: "< 1.2-3", version: "1.2", expected: false},
+		{constraint: "> 1.2", version: "1.2-1", expected: false},
+		{constraint: "= 1.2-3", version: "1.2-4", expected: false},
+		{constraint: "< 1.2", version: "1.2~rc1-1", expected: true},
+		{constraint: "> 1.2", version: "1.2^post1-1", expected: true},
+		{constraint: "<= 2:1.0.3-4.el9", version: "2:1.0.3-4.el9", expected: true},
+		{constraint: "> 2:1.0.3-4.el9", version: "3.0-1", expected: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.constraint+" "+testCase.version, func(t *testing.T) {
+			c, err := RPMConstraintFromString(testCase.constraint)
+			assert.Nil(t, err)
+			v, err := RPMVersionFromString(testCase.version)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, v.Satisfies(c))
+		})
+	}
+}
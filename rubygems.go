@@ -0,0 +1,455 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrRubyGemsVersion     = errors.New("invalid RubyGems version")
+	ErrRubyGemsRequirement = errors.New("invalid RubyGems requirement")
+)
+
+// RubyGemsVersion is a version of a Ruby gem, e.g. `4.2.11.1` or
+// `1.0.0.pre1`. RubyGems splits a version into numeric and letter segments,
+// and any letter makes the version a pre-release. Versions are ordered the
+// way `Gem::Version` orders them, see [RubyGemsVersion.Compare].
+//
+// See https://guides.rubygems.org/patterns/#prerelease-gems
+type RubyGemsVersion struct {
+	// version is the version as written, with a hyphen replaced by `.pre.`
+	// as RubyGems does, e.g. `1.0.0.pre.rc1` for `1.0.0-rc1`.
+	version  string
+	segments []rubygemsSegment
+}
+
+// rubygemsSegment is a numeric or letter segment of a RubyGems version,
+// e.g. `11` or `pre`.
+type rubygemsSegment struct {
+	number int
+	// letters is empty when the segment is numeric.
+	letters string
+}
+
+var rubygemsVersionPattern = regexp.MustCompile(`^[0-9]+(?:\.[0-9a-zA-Z]+)*(?:-[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?$`)
+
+var rubygemsSegmentPattern = regexp.MustCompile(`[0-9]+|[a-zA-Z]+`)
+
+var rubygemsConstraintPattern = regexp.MustCompile(`^(~>|!=|>=|<=|=|>|<)?\s*([0-9A-Za-z]\S*)$`)
+
+// RubyGemsVersionFromString parses a RubyGems version, e.g. `1.0.a` or
+// `4.2.11.1`. As in RubyGems, a hyphen is read as `.pre.`, so `1.0.0-rc1`
+// is the same version as `1.0.0.pre.rc1`.
+func RubyGemsVersionFromString(input string) (*RubyGemsVersion, error) {
+	trimmed := strings.TrimSpace(input)
+	if rubygemsVersionPattern.MatchString(trimmed) == false {
+		return nil, fmt.Errorf("%w: `%s`", ErrRubyGemsVersion, input)
+	}
+
+	v := &RubyGemsVersion{version: strings.ReplaceAll(trimmed, "-", ".pre.")}
+	for _, segment := range rubygemsSegmentPattern.FindAllString(v.version, -1) {
+		if isAlphaChar(segment[0]) == true {
+			v.segments = append(v.segments, rubygemsSegment{letters: segment})
+			continue
+		}
+		number, err := strconv.Atoi(segment)
+		if err != nil {
+			return nil, fmt.Errorf("%w: `%s`: %w", ErrRubyGemsVersion, input, err)
+		}
+		v.segments = append(v.segments, rubygemsSegment{number: number})
+	}
+	return v, nil
+}
+
+// rubygemsVersionFromSegments builds the version of the segments, e.g. the
+// version bumped by `~>`.
+func rubygemsVersionFromSegments(segments []rubygemsSegment) *RubyGemsVersion {
+	parts := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		parts = append(parts, segment.String())
+	}
+	return &RubyGemsVersion{version: strings.Join(parts, "."), segments: segments}
+}
+
+func (s rubygemsSegment) String() string {
+	if s.letters != "" {
+		return s.letters
+	}
+	return strconv.Itoa(s.number)
+}
+
+// String renders the version as written, except for a hyphen, which is
+// rendered as `.pre.`, e.g. `1.0.0.pre.rc1` for `1.0.0-rc1`.
+func (v *RubyGemsVersion) String() string {
+	return v.version
+}
+
+// IsPrerelease determines if the version has a letter segment, e.g.
+// `1.0.0.pre1` or `1.0.a`.
+func (v *RubyGemsVersion) IsPrerelease() bool {
+	for _, segment := range v.segments {
+		if segment.letters != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Compare evaluates the ordinality between two versions the way
+// `Gem::Version#<=>` does. Results:
+//   - `v > other => 1`
+//   - `v < other => -1`
+//   - `v == other => 0`
+//
+// The segments are compared from left to right, and a missing segment is
+// the same as `0`. Numbers are compared numerically and letters
+// character by character, while letters sort before a number in the same
+// position, e.g. `1.0.a < 1.0 < 4.2.11 < 4.2.11.1` and `1.0.a.b < 1.0.a.1`.
+// Trailing zeros of the release, and of the pre-release, are ignored, e.g.
+// `1.0` equals `1.0.0.0`, and `1.0.pre` equals `1.0.0.pre.0`.
+func (v *RubyGemsVersion) Compare(other *RubyGemsVersion) int {
+	a := v.canonicalSegments()
+	b := other.canonicalSegments()
+	for i := 0; i < max(len(a), len(b)); i += 1 {
+		lhs := rubygemsSegment{}
+		if i < len(a) {
+			lhs = a[i]
+		}
+		rhs := rubygemsSegment{}
+		if i < len(b) {
+			rhs = b[i]
+		}
+		if result := compareRubygemsSegments(lhs, rhs); result != 0 {
+			return result
+		}
+	}
+	return 0
+}
+
+func compareRubygemsSegments(a rubygemsSegment, b rubygemsSegment) int {
+	switch {
+	case a.letters == "" && b.letters == "":
+		return compareInts(a.number, b.number)
+	case a.letters == "":
+		return 1
+	case b.letters == "":
+		return -1
+	default:
+		return strings.Compare(a.letters, b.letters)
+	}
+}
+
+// splitSegments returns the segments before the first letter segment, and
+// the segments from the first letter segment on, e.g. `1 0 0` and
+// `pre 1` for `1.0.0.pre1`, without the trailing zeros of either.
+func (v *RubyGemsVersion) splitSegments() ([]rubygemsSegment, []rubygemsSegment) {
+	index := len(v.segments)
+	for i, segment := range v.segments {
+		if segment.letters != "" {
+			index = i
+			break
+		}
+	}
+	trim := func(segments []rubygemsSegment) []rubygemsSegment {
+		for len(segments) > 0 && segments[len(segments)-1] == (rubygemsSegment{}) {
+			segments = segments[:len(segments)-1]
+		}
+		return segments
+	}
+	return trim(v.segments[:index]), trim(v.segments[index:])
+}
+
+// canonicalSegments returns the segments that decide the precedence of the
+// version, as described by [RubyGemsVersion.Compare].
+func (v *RubyGemsVersion) canonicalSegments() []rubygemsSegment {
+	release, pre := v.splitSegments()
+	return append(append([]rubygemsSegment{}, release...), pre...)
+}
+
+// release returns the version without its pre-release segments, e.g.
+// `2.0.0` for `2.0.0.beta`.
+func (v *RubyGemsVersion) release() *RubyGemsVersion {
+	for i, segment := range v.segments {
+		if segment.letters != "" {
+			return rubygemsVersionFromSegments(v.segments[:i])
+		}
+	}
+	return v
+}
+
+// bump returns the version that bounds `~>` from above: the last segment of
+// the release but one is incremented, and the segments after it are
+// dropped, e.g. `2.4` for `2.3.1` and `3` for `2.3`.
+func (v *RubyGemsVersion) bump() *RubyGemsVersion {
+	segments := append([]rubygemsSegment{}, v.release().segments...)
+	if len(segments) > 1 {
+		segments = segments[:len(segments)-1]
+	}
+	segments[len(segments)-1].number += 1
+	return rubygemsVersionFromSegments(segments)
+}
+
+// Semver converts the version to the semver [Version] that has the same
+// precedence, e.g. `1.0.0.pre1` to `1.0.0-pre.1`. The segments before the
+// first letter are the release, and the segments from the first letter on
+// are the pre-release identifiers:
+//
+//   - `1.0.a` is converted to `1.0.0-a`
+//   - `1.0.0-rc1` is converted to `1.0.0-pre.rc.1`
+//
+// Trailing zeros are ignored, as they are by RubyGems, e.g. `1.0.0.0` is
+// converted to `1.0.0`. Not every RubyGems version can be represented, so
+// the returned descriptions report what did not survive the conversion. The
+// conversion is lossless when none are returned. A release with more than
+// three segments, e.g. `4.2.11.1`, is truncated to `4.2.11`.
+//
+// RubyGems orders a letter segment before a number in the same position,
+// e.g. `1.0.a.b < 1.0.a.1`, while semver orders alphanumeric identifiers
+// after numeric ones. Pre-releases that only differ in such a position,
+// which is uncommon, are ordered differently once converted.
+func (v *RubyGemsVersion) Semver() (*Version, []string) {
+	losses := make([]string, 0)
+	release, pre := v.splitSegments()
+
+	components := make([]int, 3)
+	for i, segment := range release[:min(3, len(release))] {
+		components[i] = segment.number
+	}
+	if len(release) > 3 {
+		losses = append(losses, fmt.Sprintf("release `%s` is truncated to `%d.%d.%d`", v.release(), components[0], components[1], components[2]))
+	}
+
+	identifiers := make([]string, 0, len(pre))
+	for _, segment := range pre {
+		identifiers = append(identifiers, segment.String())
+	}
+	return newVersion(components[0], components[1], components[2], strings.Join(identifiers, ".")), losses
+}
+
+// RubyGemsRequirement is a RubyGems requirement, as written in a Gemfile or
+// gemspec, e.g. `~> 4.2.11, >= 4.2.11.1`. A version satisfies the
+// requirement when it satisfies every constraint of it.
+//
+// Unlike a [Range] parsed with [DialectRubyGems], a requirement is
+// evaluated against [RubyGemsVersion] values, so versions with more than
+// three release segments are supported.
+type RubyGemsRequirement struct {
+	constraints []rubygemsConstraint
+}
+
+// rubygemsConstraint is a single constraint of a requirement, e.g. `~> 2.3`.
+type rubygemsConstraint struct {
+	operator string
+	version  *RubyGemsVersion
+}
+
+// RubyGemsRequirementFromString parses a comma separated list of RubyGems
+// constraints, e.g. `>= 1.0, < 3`. The supported operators are `=`, `!=`,
+// `>`, `<`, `>=`, `<=` and `~>`, and a bare version is the same as `=`.
+func RubyGemsRequirementFromString(input string) (*RubyGemsRequirement, error) {
+	trimmed := strings.TrimSpace(input)
+	if trimmed == "" {
+		return nil, fmt.Errorf("%w: empty requirement", ErrRubyGemsRequirement)
+	}
+
+	requirement := &RubyGemsRequirement{constraints: make([]rubygemsConstraint, 0)}
+	for _, piece := range strings.Split(trimmed, ",") {
+		piece = strings.TrimSpace(piece)
+		if piece == "" {
+			return nil, fmt.Errorf("%w: empty constraint in `%s`", ErrRubyGemsRequirement, trimmed)
+		}
+		matches := rubygemsConstraintPattern.FindStringSubmatch(piece)
+		if matches == nil {
+			return nil, fmt.Errorf("%w: `%s`", ErrRubyGemsRequirement, piece)
+		}
+		v, err := RubyGemsVersionFromString(matches[2])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrRubyGemsRequirement, err)
+		}
+		constraint := rubygemsConstraint{operator: matches[1], version: v}
+		if constraint.operator == "" {
+			constraint.operator = "="
+		}
+		requirement.constraints = append(requirement.constraints, constraint)
+	}
+	return requirement, nil
+}
+
+// String renders the constraints separated by commas, as RubyGems does,
+// e.g. `~> 2.3, >= 2.3.1`.
+func (r *RubyGemsRequirement) String() string {
+	parts := make([]string, 0, len(r.constraints))
+	for _, constraint := range r.constraints {
+		parts = append(parts, constraint.operator+" "+constraint.version.String())
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Satisfies determines if the version is covered by the provided
+// [RubyGemsRequirement]. `~> 2.3.1` is satisfied by a version that is at
+// least `2.3.1`, and whose release is lower than `2.4`, e.g. `2.3.9.1`.
+//
+// As when RubyGems and Bundler resolve a dependency, a pre-release only
+// satisfies a requirement that names a pre-release, e.g. `>= 1.0.a` is
+// satisfied by `2.0.0.beta`, while `>= 1.0` is not.
+func (v *RubyGemsVersion) Satisfies(r *RubyGemsRequirement) bool {
+	return v.SatisfiesWithOptions(r, Options{})
+}
+
+// SatisfiesWithOptions is the same as [RubyGemsVersion.Satisfies] with the
+// evaluation altered by the provided [Options]. Only
+// [Options.IncludePrerelease] applies to RubyGems requirements.
+func (v *RubyGemsVersion) SatisfiesWithOptions(r *RubyGemsRequirement, opts Options) bool {
+	if v.IsPrerelease() == true && opts.IncludePrerelease == false && r.namesPrerelease() == false {
+		return false
+	}
+	for _, constraint := range r.constraints {
+		if constraint.contains(v) == false {
+			return false
+		}
+	}
+	return true
+}
+
+// Check determines if the version satisfies the requirement, as described
+// by [RubyGemsVersion.Satisfies]. It makes a requirement a [Constraint] of
+// RubyGems versions.
+func (r *RubyGemsRequirement) Check(v *RubyGemsVersion) bool {
+	return v.Satisfies(r)
+}
+
+// namesPrerelease determines if any constraint of the requirement names a
+// pre-release.
+func (r *RubyGemsRequirement) namesPrerelease() bool {
+	for _, constraint := range r.constraints {
+		if constraint.version.IsPrerelease() == true {
+			return true
+		}
+	}
+	return false
+}
+
+func (c rubygemsConstraint) contains(v *RubyGemsVersion) bool {
+	result := v.Compare(c.version)
+	switch c.operator {
+	case "=":
+		return result == 0
+	case "!=":
+		return result != 0
+	case ">":
+		return result > 0
+	case "<":
+		return result < 0
+	case ">=":
+		return result >= 0
+	case "<=":
+		return result <= 0
+	default:
+		// ~>
+		return result >= 0 && v.release().Compare(c.version.bump()) < 0
+	}
+}
+
+// parseRubygemsRange parses a RubyGems requirement, as written in a Gemfile
+// or gemspec, e.g. `~> 2.3, >= 2.3.1`. RubyGems differs from npm in a few
+// ways:
+//
+//   - constraints are separated by commas, and there is no `||`
+//   - a bare version is exact, and versions are converted as described by
+//     [RubyGemsVersion.Semver], e.g. `1.0.0.pre1` means `=1.0.0-pre.1`
+//   - `~>` allows the last release segment but one to increase, e.g.
+//     `~> 2.3` means `>=2.3.0 <3.0.0-0`, while `~> 2.3.1` means
+//     `>=2.3.1 <2.4.0-0`
+//
+// As when RubyGems and Bundler resolve a dependency, a requirement that
+// names a pre-release allows any pre-release within its bounds, e.g.
+// `>= 1.0.a` is satisfied by `2.0.0.beta`.
+//
+// A version that can not be converted without loss, e.g. `4.2.11.1`, is
+// rejected. A [RubyGemsRequirement] supports those.
+//
+// See https://guides.rubygems.org/patterns/#pessimistic-version-constraint
+func parseRubygemsRange(input string, opts Options) (*Range, error) {
+	trimmed := strings.TrimSpace(input)
+	if trimmed == "" {
+		return nil, fmt.Errorf("%w: empty rubygems requirement", ErrRangeSyntax)
+	}
+
+	comparators := make([]*Comparator, 0)
+	policy := namedPrereleasePolicy{}
+	for _, piece := range strings.Split(trimmed, ",") {
+		piece = strings.TrimSpace(piece)
+		if piece == "" {
+			return nil, fmt.Errorf("%w: empty constraint in `%s`", ErrRangeSyntax, trimmed)
+		}
+		desugared, err := parseRubygemsComparator(piece)
+		if err != nil {
+			return nil, err
+		}
+		if desugared[0].version.pre != "" && policy.namedBy == "" {
+			policy.namedBy = piece
+		}
+		comparators = append(comparators, desugared...)
+	}
+
+	set := newComparatorSet(comparators...)
+	set.prerelease = policy
+	return rangeFromSets([]ComparatorSet{set}, []string{trimmed}, opts)
+}
+
+func parseRubygemsComparator(input string) ([]*Comparator, error) {
+	matches := rubygemsConstraintPattern.FindStringSubmatch(input)
+	if matches == nil {
+		rest := strings.TrimLeftFunc(input, func(r rune) bool {
+			return r < 128 && isAlphaChar(byte(r)) == false && isIntegerChar(byte(r)) == false && r != ' ' && r != '\t'
+		})
+		operator := input[:len(input)-len(rest)]
+		if strings.ContainsAny(strings.TrimSpace(rest), " \t") == true {
+			return nil, fmt.Errorf("%w: constraints in `%s` must be separated by commas", ErrRangeSyntax, input)
+		}
+		return nil, fmt.Errorf("%w: `%s`", ErrRangeOperator, operator)
+	}
+
+	operator, rest := matches[1], matches[2]
+	rv, err := RubyGemsVersionFromString(rest)
+	if err != nil {
+		if isAlphaChar(rest[0]) == true {
+			return nil, fmt.Errorf("%w: `%s`", ErrRangeAlpha, rest)
+		}
+		return nil, fmt.Errorf("%w: %w", ErrRangeSyntax, err)
+	}
+	v, losses := rv.Semver()
+	if len(losses) > 0 {
+		return nil, fmt.Errorf("%w: `%s` can not be represented as semver: %s", ErrRangeSyntax, input, strings.Join(losses, ", "))
+	}
+
+	switch operator {
+	case "", "=":
+		return []*Comparator{{operator: OperatorEqual, version: v}}, nil
+	case "!=":
+		return []*Comparator{{operator: OperatorNotEqual, version: v}}, nil
+	case ">":
+		return []*Comparator{{operator: OperatorGreaterThan, version: v}}, nil
+	case ">=":
+		return []*Comparator{{operator: OperatorGreaterThanEqual, version: v}}, nil
+	case "<":
+		return []*Comparator{{operator: OperatorLessThan, version: v}}, nil
+	case "<=":
+		return []*Comparator{{operator: OperatorLessThanEqual, version: v}}, nil
+	default:
+		// ~> is satisfied by versions whose release segments are lower than
+		// the bumped version, so none of its pre-releases satisfy it.
+		upper, losses := rv.bump().Semver()
+		if len(losses) > 0 {
+			return nil, fmt.Errorf("%w: `%s` can not be represented as semver: %s", ErrRangeSyntax, input, strings.Join(losses, ", "))
+		}
+		upper.pre = "0"
+		return []*Comparator{
+			{operator: OperatorGreaterThanEqual, version: v},
+			{operator: OperatorLessThan, version: upper},
+		}, nil
+	}
+}
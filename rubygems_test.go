@@ -0,0 +1,288 @@
+package semver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRubyGemsVersionFromString(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{input: "1", expected: "1"},
+		{input: " 2.3.1 ", expected: "2.3.1"},
+		{input: "4.2.11.1", expected: "4.2.11.1"},
+		{input: "1.0.0.pre1", expected: "1.0.0.pre1"},
+		{input: "1.0a", expected: "1.0a"},
+		{input: "1.0.0-rc1", expected: "1.0.0.pre.rc1"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			v, err := RubyGemsVersionFromString(testCase.input)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, v.String())
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		for _, input := range []string{"", "v1.0", "1..0", "1.0.", "a.1", "1.0+build"} {
+			v, err := RubyGemsVersionFromString(input)
+			assert.Nil(t, v)
+			assert.ErrorIs(t, err, ErrRubyGemsVersion, input)
+		}
+	})
+}
+
+func TestRubyGemsVersion_Compare(t *testing.T) {
+	// Every version is lower than the versions after it, as ordered by
+	// `Gem::Version#<=>`.
+	ordered := []string{
+		"0.9", "1.0.a", "1.0.0.alpha", "1.0.0.b", "1.0.0.pre", "1.0.0.pre1", "1.0.0.pre2", "1.0.0.rc1", "1.0",
+		"1.0.1", "1.1.a", "1.1", "4.2.10", "4.2.11", "4.2.11.1", "4.2.11.2", "4.2.11.10", "4.2.12.a", "4.2.12",
+		"4.2.12.0.1",
+	}
+	for i := 0; i < len(ordered)-1; i += 1 {
+		a, _ := RubyGemsVersionFromString(ordered[i])
+		b, _ := RubyGemsVersionFromString(ordered[i+1])
+		assert.Equal(t, -1, a.Compare(b), ordered[i]+" < "+ordered[i+1])
+		assert.Equal(t, 1, b.Compare(a), ordered[i+1]+" > "+ordered[i])
+	}
+
+	t.Run("letters sort before numbers", func(t *testing.T) {
+		a, _ := RubyGemsVersionFromString("1.0.a.b")
+		b, _ := RubyGemsVersionFromString("1.0.a.1")
+		assert.Equal(t, -1, a.Compare(b))
+	})
+
+	equal := [][]string{
+		{"1.0", "1.0.0.0"},
+		{"1.01", "1.1"},
+		{"1.0.pre", "1.0.0.pre.0"},
+		{"1.0.0-rc1", "1.0.0.pre.rc1"},
+		{"4.2.11.1", "4.2.11.1.0"},
+	}
+	for _, pair := range equal {
+		a, _ := RubyGemsVersionFromString(pair[0])
+		b, _ := RubyGemsVersionFromString(pair[1])
+		assert.Equal(t, 0, a.Compare(b), pair[0]+" == "+pair[1])
+	}
+}
+
+func TestRubyGemsVersion_Semver(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+		losses   int
+	}{
+		{input: "1", expected: "1.0.0"},
+		{input: "2.3", expected: "2.3.0"},
+		{input: "1.0.0.0", expected: "1.0.0"},
+		{input: "1.0.0.pre1", expected: "1.0.0-pre.1"},
+		{input: "1.0.a", expected: "1.0.0-a"},
+		{input: "1.0a", expected: "1.0.0-a"},
+		{input: "2.0.0.beta.2", expected: "2.0.0-beta.2"},
+		{input: "1.0.0.rc01", expected: "1.0.0-rc.1"},
+		{input: "1.0.0.pre.0", expected: "1.0.0-pre"},
+		{input: "1.0.0-rc1", expected: "1.0.0-pre.rc.1"},
+		{input: "1.2.3.0.a", expected: "1.2.3-a"},
+		{input: "4.2.11.1", expected: "4.2.11", losses: 1},
+		{input: "4.2.11.1.rc1", expected: "4.2.11-rc.1", losses: 1},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			v, _ := RubyGemsVersionFromString(testCase.input)
+			converted, losses := v.Semver()
+			assert.Equal(t, testCase.expected, converted.String())
+			assert.Equal(t, testCase.losses, len(losses), losses)
+		})
+	}
+
+	t.Run("preserves the order of lossless conversions", func(t *testing.T) {
+		ordered := []string{"0.9", "1.0.a", "1.0.0.alpha", "1.0.0.b", "1.0.0.pre", "1.0.0.pre1", "1.0.0.pre2", "1.0.0.rc1", "1.0", "1.0.1", "1.1.a", "1.1"}
+		for i := 0; i < len(ordered)-1; i += 1 {
+			a, _ := RubyGemsVersionFromString(ordered[i])
+			b, _ := RubyGemsVersionFromString(ordered[i+1])
+			sa, _ := a.Semver()
+			sb, _ := b.Semver()
+			assert.Equal(t, true, sa.Less(sb), ordered[i]+" < "+ordered[i+1])
+		}
+	})
+}
+
+func TestRubyGemsRequirementFromString(t *testing.T) {
+	r, err := RubyGemsRequirementFromString(" ~>4.2.11,>= 4.2.11.1 , 1.0-rc1")
+	assert.Nil(t, err)
+	assert.Equal(t, "~> 4.2.11, >= 4.2.11.1, = 1.0.pre.rc1", r.String())
+
+	t.Run("errors", func(t *testing.T) {
+		for _, input := range []string{"", ">= 1.0,", ">= 1.0 < 3", "=> 1.2", "^1.2", ">= a.b", "1.2 || 2.0"} {
+			r, err := RubyGemsRequirementFromString(input)
+			assert.Nil(t, r)
+			assert.ErrorIs(t, err, ErrRubyGemsRequirement, input)
+		}
+	})
+}
+
+func TestRubyGemsVersion_Satisfies(t *testing.T) {
+	testCases := []struct {
+		requirement string
+		version     string
+		expected    bool
+	}{
+		{requirement: "~> 2.3", version: "2.9.9", expected: true},
+		{requirement: "~> 2.3", version: "3.0", expected: false},
+		{requirement: "~> 2.3.1", version: "2.3.9.1", expected: true},
+		{requirement: "~> 2.3.1", version: "2.4", expected: false},
+		{requirement: ">= 1.0, != 2.1.0", version: "2.1", expected: false},
+
+		// Versions with more than three release segments.
+		{requirement: "~> 4.2.11", version: "4.2.11.1", expected: true},
+		{requirement: "~> 4.2.11.1", version: "4.2.11", expected: false},
+		{requirement: "~> 4.2.11.1", version: "4.2.11.9", expected: true},
+		{requirement: "~> 4.2.11.1", version: "4.2.12", expected: false},
+		{requirement: "> 4.2.11", version: "4.2.11.1", expected: true},
+		{requirement: "= 4.2.11.1", version: "4.2.11.1.0", expected: true},
+
+		// Pre-releases only satisfy a requirement that names one.
+		{requirement: ">= 1.0", version: "2.0.0.pre1", expected: false},
+		{requirement: ">= 1.0.a", version: "2.0.0.beta", expected: true},
+		{requirement: "~> 1.0.0.pre1", version: "1.0.0.pre", expected: false},
+		{requirement: "~> 1.0.0.pre1", version: "1.0.0.pre2", expected: true},
+		{requirement: "~> 1.0.0.pre1", version: "1.0.9", expected: true},
+		{requirement: "~> 1.0.0.pre1", version: "1.1.a", expected: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.requirement+" "+testCase.version, func(t *testing.T) {
+			r, err := RubyGemsRequirementFromString(testCase.requirement)
+			assert.Nil(t, err)
+			v, err := RubyGemsVersionFromString(testCase.version)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, v.Satisfies(r))
+			assert.Equal(t, testCase.expected, r.Check(v))
+		})
+	}
+
+	t.Run("include pre-releases", func(t *testing.T) {
+		r, _ := RubyGemsRequirementFromString(">= 1.0")
+		v, _ := RubyGemsVersionFromString("2.0.0.pre1")
+		assert.Equal(t, true, v.SatisfiesWithOptions(r, Options{IncludePrerelease: true}))
+	})
+}
+
+func TestParseRange_RubyGems(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		// Exact versions.
+		{input: "1.2.3", expected: "=1.2.3"},
+		{input: "= 1.2", expected: "=1.2.0"},
+		{input: "=1.0.0.pre1", expected: "=1.0.0-pre.1"},
+
+		// Comparison operators.
+		{input: "> 1.2", expected: ">1.2.0"},
+		{input: ">= 1.0", expected: ">=1.0.0"},
+		{input: "< 3", expected: "<3.0.0"},
+		{input: "<= 1.2.3", expected: "<=1.2.3"},
+		{input: "!= 2.1.0", expected: "!=2.1.0"},
+
+		// Pessimistic operator.
+		{input: "~> 2", expected: ">=2.0.0 <3.0.0-0"},
+		{input: "~> 2.3", expected: ">=2.3.0 <3.0.0-0"},
+		{input: "~> 2.3.0", expected: ">=2.3.0 <2.4.0-0"},
+		{input: "~> 2.3.1", expected: ">=2.3.1 <2.4.0-0"},
+		{input: "~>2.0.0.beta", expected: ">=2.0.0-beta <2.1.0-0"},
+		{input: "~> 1.2.3.0", expected: ">=1.2.3 <1.2.4-0"},
+
+		// Multiple constraints.
+		{input: ">= 1.0, < 3", expected: ">=1.0.0 <3.0.0"},
+		{input: "~> 2.3, >= 2.3.1", expected: ">=2.3.0 <3.0.0-0 >=2.3.1"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			r, err := ParseRange(testCase.input, DialectRubyGems)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, r.String())
+		})
+	}
+
+	t.Run("raw keeps the requirement", func(t *testing.T) {
+		r, err := ParseRange(" ~> 2.3, != 2.3.4 ", DialectRubyGems)
+		assert.Nil(t, err)
+		assert.Equal(t, "~> 2.3, != 2.3.4", r.Raw())
+	})
+
+	t.Run("satisfies", func(t *testing.T) {
+		testCases := []struct {
+			input    string
+			version  string
+			expected bool
+		}{
+			{input: "~> 2.3", version: "2.9.9", expected: true},
+			{input: "~> 2.3", version: "3.0.0", expected: false},
+			{input: "~> 2.3.1", version: "2.3.9", expected: true},
+			{input: "~> 2.3.1", version: "2.4.0", expected: false},
+			{input: ">= 1.0, < 3", version: "2.9.0", expected: true},
+			{input: ">= 1.0, != 2.1.0", version: "2.1.0", expected: false},
+
+			// Pre-releases only satisfy a requirement that names one.
+			{input: ">= 1.0", version: "2.0.0-pre.1", expected: false},
+			{input: ">= 1.0.a", version: "2.0.0-beta", expected: true},
+			{input: "~> 2.0.0.beta", version: "2.0.0-rc.1", expected: true},
+			{input: "~> 2.0.0.beta", version: "2.0.0-alpha", expected: false},
+			{input: "~> 1.0.a", version: "2.0.0-a", expected: false},
+		}
+		for _, testCase := range testCases {
+			r, err := ParseRange(testCase.input, DialectRubyGems)
+			assert.Nil(t, err)
+			v, _ := VersionFromString(testCase.version)
+			assert.Equal(t, testCase.expected, v.Satisfies(r), testCase.input+" "+testCase.version)
+		}
+	})
+
+	t.Run("satisfied by rubygems versions", func(t *testing.T) {
+		r, _ := ParseRange("~> 1.0.0.pre1", DialectRubyGems)
+		testCases := []struct {
+			version  string
+			expected bool
+		}{
+			{version: "1.0.0.pre", expected: false},
+			{version: "1.0.0.pre2", expected: true},
+			{version: "1.0.0", expected: true},
+			{version: "1.0.9", expected: true},
+			{version: "1.1.a", expected: false},
+		}
+		for _, testCase := range testCases {
+			rv, err := RubyGemsVersionFromString(testCase.version)
+			assert.Nil(t, err)
+			v, _ := rv.Semver()
+			assert.Equal(t, testCase.expected, v.Satisfies(r), testCase.version)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		testCases := []struct {
+			input    string
+			expected error
+		}{
+			{input: "", expected: ErrRangeSyntax},
+			{input: ">= 1.0,", expected: ErrRangeSyntax},
+			{input: ">= 1.0 < 3", expected: ErrRangeSyntax},
+			{input: "1.2 || 2.0", expected: ErrRangeSyntax},
+			{input: "~> 1.2.3.4.5", expected: ErrRangeSyntax},
+			{input: "=> 1.2", expected: ErrRangeOperator},
+			{input: "^1.2", expected: ErrRangeOperator},
+			{input: ">= a.b", expected: ErrRangeAlpha},
+		}
+		for _, testCase := range testCases {
+			r, err := ParseRange(testCase.input, DialectRubyGems)
+			assert.Nil(t, r)
+			assert.ErrorIs(t, err, testCase.expected, testCase.input)
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package semver
+
+import (
+	"sort"
+)
+
+// Orderable is a version of a versioning scheme, that can be ordered
+// against other versions of the same scheme. `Compare` must return `1` when
+// the version is greater than the other, `-1` when it is lower, and `0`
+// when they are equal. [Version], [PEP440Version], [DebianVersion],
+// [RPMVersion] and [RubyGemsVersion] are orderable.
+//
+// The helpers of this package, e.g. [Sort] and [MaxSatisfying], are
+// written against Orderable and [Constraint], so a versioning scheme that
+// is not provided by this package can use them by implementing both.
+type Orderable[T any] interface {
+	Compare(other T) int
+}
+
+// Constraint is a constraint on the versions of a versioning scheme, e.g. a
+// [Range] for a [Version]. `Check` reports whether the version satisfies
+// the constraint. [Range], [PEP440Specifier], [DebianConstraint],
+// [RPMConstraint] and [RubyGemsRequirement] are constraints.
+type Constraint[T any] interface {
+	Check(v T) bool
+}
+
+// Sort sorts the versions from lowest to highest, in place. Versions that
+// are equal keep their order, e.g. `1.2.3+a` and `1.2.3+b`.
+func Sort[T Orderable[T]](versions []T) {
+	sort.SliceStable(versions, func(a int, b int) bool {
+		return versions[a].Compare(versions[b]) < 0
+	})
+}
+
+// Filter returns the versions that satisfy the constraint, in the order they
+// are provided.
+func Filter[T any](versions []T, c Constraint[T]) []T {
+	result := make([]T, 0, len(versions))
+	for _, v := range versions {
+		if c.Check(v) == true {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// MaxSatisfying returns the highest of the versions that satisfy the
+// constraint. When versions that are equal are the highest, the first of
+// them is returned. The boolean is false, and the version is the zero
+// value, when none of the versions satisfy the constraint.
+func MaxSatisfying[T Orderable[T]](versions []T, c Constraint[T]) (T, bool) {
+	var max T
+	found := false
+	for _, v := range versions {
+		if c.Check(v) == false {
+			continue
+		}
+		if found == false || v.Compare(max) > 0 {
+			max = v
+			found = true
+		}
+	}
+	return max, found
+}
+
+// Intersect combines constraints into one that a version satisfies when it
+// satisfies every one of them, e.g. a [Range] of a manifest and a [Range]
+// of known vulnerable versions. Without any constraints, every version
+// satisfies the result.
+func Intersect[T any](constraints ...Constraint[T]) Constraint[T] {
+	return intersection[T](constraints)
+}
+
+// intersection is the result of [Intersect].
+type intersection[T any] []Constraint[T]
+
+func (i intersection[T]) Check(v T) bool {
+	for _, c := range i {
+		if c.Check(v) == false {
+			return false
+		}
+	}
+	return true
+}
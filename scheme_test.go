@@ -0,0 +1,220 @@
+package semver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// buildNumber is a versioning scheme that is not provided by the package,
+// e.g. the build number of an application.
+type buildNumber int
+
+func (b buildNumber) Compare(other buildNumber) int {
+	return compareInts(int(b), int(other))
+}
+
+// minimumBuild is a constraint on build numbers.
+type minimumBuild int
+
+func (m minimumBuild) Check(b buildNumber) bool {
+	return int(b) >= int(m)
+}
+
+func versionsFromStrings(inputs ...string) []*Version {
+	result := make([]*Version, 0, len(inputs))
+	for _, input := range inputs {
+		v, _ := VersionFromString(input)
+		result = append(result, v)
+	}
+	return result
+}
+
+func versionStrings[T interface{ String() string }](versions []T) []string {
+	result := make([]string, 0, len(versions))
+	for _, v := range versions {
+		result = append(result, v.String())
+	}
+	return result
+}
+
+func TestSort(t *testing.T) {
+	t.Run("semver", func(t *testing.T) {
+		versions := versionsFromStrings("1.10.0", "1.2.3+b", "1.2.3-beta", "0.9.0", "1.2.3+a", "1.2.3-alpha.10", "1.2.3-alpha.2")
+		Sort(versions)
+		expected := []string{"0.9.0", "1.2.3-alpha.2", "1.2.3-alpha.10", "1.2.3-beta", "1.2.3+b", "1.2.3+a", "1.10.0"}
+		assert.Equal(t, expected, versionStrings(versions))
+	})
+
+	t.Run("pep 440", func(t *testing.T) {
+		versions := make([]*PEP440Version, 0)
+		for _, input := range []string{"1.0.post1", "1!0.1", "1.0", "1.0rc1", "1.0.dev1"} {
+			v, _ := PEP440VersionFromString(input)
+			versions = append(versions, v)
+		}
+		Sort(versions)
+		assert.Equal(t, []string{"1.0.dev1", "1.0rc1", "1.0", "1.0.post1", "1!0.1"}, versionStrings(versions))
+	})
+
+	t.Run("debian", func(t *testing.T) {
+		versions := make([]*DebianVersion, 0)
+		for _, input := range []string{"1:2.30-1", "2.30-1ubuntu3.2", "2.30~rc1-1", "2.30-1"} {
+			v, _ := DebianVersionFromString(input)
+			versions = append(versions, v)
+		}
+		Sort(versions)
+		assert.Equal(t, []string{"2.30~rc1-1", "2.30-1", "2.30-1ubuntu3.2", "1:2.30-1"}, versionStrings(versions))
+	})
+
+	t.Run("rpm", func(t *testing.T) {
+		versions := make([]*RPMVersion, 0)
+		for _, input := range []string{"1.0^post1", "2:1.0.3-4.el9", "1.0", "1.0~rc1"} {
+			v, _ := RPMVersionFromString(input)
+			versions = append(versions, v)
+		}
+		Sort(versions)
+		assert.Equal(t, []string{"1.0~rc1", "1.0", "1.0^post1", "2:1.0.3-4.el9"}, versionStrings(versions))
+
+		versions = make([]*RPMVersion, 0)
+		for _, input := range []string{"1.0-4", "1.0", "1.0-3", "1.0-4", "1.0", "1.0-3"} {
+			v, _ := RPMVersionFromString(input)
+			versions = append(versions, v)
+		}
+		Sort(versions)
+		assert.Equal(t, []string{"1.0", "1.0", "1.0-3", "1.0-3", "1.0-4", "1.0-4"}, versionStrings(versions))
+	})
+
+	t.Run("rubygems", func(t *testing.T) {
+		versions := make([]*RubyGemsVersion, 0)
+		for _, input := range []string{"4.2.11.1", "4.2.11", "4.2.11.1.rc1", "4.2.10.10", "4.2.12"} {
+			v, _ := RubyGemsVersionFromString(input)
+			versions = append(versions, v)
+		}
+		Sort(versions)
+		assert.Equal(t, []string{"4.2.10.10", "4.2.11", "4.2.11.1.rc1", "4.2.11.1", "4.2.12"}, versionStrings(versions))
+	})
+
+	t.Run("other schemes", func(t *testing.T) {
+		builds := []buildNumber{42, 7, 1000}
+		Sort(builds)
+		assert.Equal(t, []buildNumber{7, 42, 1000}, builds)
+	})
+}
+
+func TestFilter(t *testing.T) {
+	t.Run("semver", func(t *testing.T) {
+		r, _ := RangeFromString("^1.2.0")
+		versions := versionsFromStrings("2.0.0", "1.2.3", "1.3.0-beta", "1.1.0", "1.9.0")
+		assert.Equal(t, []string{"1.2.3", "1.9.0"}, versionStrings(Filter(versions, r)))
+	})
+
+	t.Run("debian", func(t *testing.T) {
+		c, _ := DebianConstraintFromString(">= 2.30-1, << 2.31")
+		versions := make([]*DebianVersion, 0)
+		for _, input := range []string{"2.31-1", "2.30-2", "2.29-1", "2.31~rc1-1"} {
+			v, _ := DebianVersionFromString(input)
+			versions = append(versions, v)
+		}
+		assert.Equal(t, []string{"2.30-2", "2.31~rc1-1"}, versionStrings(Filter(versions, c)))
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		r, _ := RangeFromString(">5.0.0")
+		assert.Equal(t, []*Version{}, Filter(versionsFromStrings("1.0.0"), r))
+	})
+}
+
+func TestMaxSatisfying(t *testing.T) {
+	t.Run("semver", func(t *testing.T) {
+		r, _ := RangeFromString("~1.2.0")
+		max, found := MaxSatisfying(versionsFromStrings("1.2.9", "1.3.0", "1.2.10", "1.2.11-beta"), r)
+		assert.Equal(t, true, found)
+		assert.Equal(t, "1.2.10", max.String())
+	})
+
+	t.Run("equal versions", func(t *testing.T) {
+		r, _ := RangeFromString("*")
+		max, found := MaxSatisfying(versionsFromStrings("1.0.0+b", "1.0.0+a"), r)
+		assert.Equal(t, true, found)
+		assert.Equal(t, "1.0.0+b", max.String())
+	})
+
+	t.Run("pep 440", func(t *testing.T) {
+		s, _ := PEP440SpecifierFromString("~=2.2")
+		versions := make([]*PEP440Version, 0)
+		for _, input := range []string{"2.2", "2.9rc1", "2.8.post1", "3.0"} {
+			v, _ := PEP440VersionFromString(input)
+			versions = append(versions, v)
+		}
+		max, found := MaxSatisfying(versions, s)
+		assert.Equal(t, true, found)
+		assert.Equal(t, "2.8.post1", max.String())
+	})
+
+	t.Run("rpm", func(t *testing.T) {
+		c, _ := RPMConstraintFromString("< 1.2")
+		versions := make([]*RPMVersion, 0)
+		for _, input := range []string{"1.1-3.el9", "1.2~rc1-1", "1.2-1"} {
+			v, _ := RPMVersionFromString(input)
+			versions = append(versions, v)
+		}
+		max, found := MaxSatisfying(versions, c)
+		assert.Equal(t, true, found)
+		assert.Equal(t, "1.2~rc1-1", max.String())
+	})
+
+	t.Run("rubygems", func(t *testing.T) {
+		r, _ := RubyGemsRequirementFromString("~> 4.2.11")
+		versions := make([]*RubyGemsVersion, 0)
+		for _, input := range []string{"4.2.11", "4.2.11.3", "4.2.11.1", "4.3.0"} {
+			v, _ := RubyGemsVersionFromString(input)
+			versions = append(versions, v)
+		}
+		max, found := MaxSatisfying(versions, r)
+		assert.Equal(t, true, found)
+		assert.Equal(t, "4.2.11.3", max.String())
+	})
+
+	t.Run("other schemes", func(t *testing.T) {
+		max, found := MaxSatisfying([]buildNumber{42, 7, 1000}, minimumBuild(10))
+		assert.Equal(t, true, found)
+		assert.Equal(t, buildNumber(1000), max)
+	})
+
+	t.Run("none satisfy", func(t *testing.T) {
+		r, _ := RangeFromString(">5.0.0")
+		max, found := MaxSatisfying(versionsFromStrings("1.0.0", "2.0.0"), r)
+		assert.Equal(t, false, found)
+		assert.Nil(t, max)
+	})
+}
+
+func TestIntersect(t *testing.T) {
+	manifest, _ := RangeFromString("^1.2.0")
+	vulnerable, _ := ParseRange("[1.4.0,1.5.2)", DialectNuGet)
+	affected := Intersect[*Version](manifest, vulnerable)
+
+	testCases := []struct {
+		version  string
+		expected bool
+	}{
+		{version: "1.3.0", expected: false},
+		{version: "1.4.0", expected: true},
+		{version: "1.5.1", expected: true},
+		{version: "1.5.2", expected: false},
+		{version: "2.0.0", expected: false},
+	}
+	for _, testCase := range testCases {
+		v, _ := VersionFromString(testCase.version)
+		assert.Equal(t, testCase.expected, affected.Check(v), testCase.version)
+	}
+
+	t.Run("without constraints", func(t *testing.T) {
+		builds := []buildNumber{1, 2, 3}
+		assert.Equal(t, builds, Filter(builds, Intersect[buildNumber]()))
+	})
+
+	t.Run("other schemes", func(t *testing.T) {
+		c := Intersect[buildNumber](minimumBuild(2), minimumBuild(3))
+		assert.Equal(t, []buildNumber{3, 4}, Filter([]buildNumber{1, 2, 3, 4}, c))
+	})
+}
@@ -1,5 +1,10 @@
 package semver
 
+import (
+	"strconv"
+	"strings"
+)
+
 // Compare evaluates the ordinality between two versions.
 // Results:
 //   - `a > b => 1`
@@ -29,8 +34,68 @@ func Compare(a *Version, b *Version) int {
 		return -1
 	}
 
-	// todo: compare pre-release tags
+	// Major, minor, and patch versions are all equal, so precedence is decided
+	// by the pre-release identifiers. Build metadata never factors into
+	// precedence.
+	return comparePre(a.pre, b.pre)
+}
+
+// comparePre evaluates the ordinality between two pre-release strings
+// according to item 11 of the spec. An empty string indicates the version
+// does not have a pre-release, which gives it a higher precedence than any
+// version that does.
+func comparePre(a string, b string) int {
+	if a == b {
+		return 0
+	}
+	if a == "" {
+		return 1
+	}
+	if b == "" {
+		return -1
+	}
+
+	aIdentifiers := strings.Split(a, ".")
+	bIdentifiers := strings.Split(b, ".")
+	for i := 0; i < len(aIdentifiers) && i < len(bIdentifiers); i += 1 {
+		result := compareIdentifier(aIdentifiers[i], bIdentifiers[i])
+		if result != 0 {
+			return result
+		}
+	}
 
-	// Major, minor, and patch versions are all equal so versions are equal.
-	return 0
+	// All shared identifiers are equal, so the larger set of identifiers has
+	// the higher precedence.
+	switch {
+	case len(aIdentifiers) > len(bIdentifiers):
+		return 1
+	case len(aIdentifiers) < len(bIdentifiers):
+		return -1
+	default:
+		return 0
+	}
+}
+
+// compareIdentifier compares a single pair of pre-release identifiers.
+// Numeric identifiers are compared numerically and always have a lower
+// precedence than alphanumeric identifiers, which are compared lexically.
+func compareIdentifier(a string, b string) int {
+	aNum, aErr := strconv.ParseUint(a, 10, 64)
+	bNum, bErr := strconv.ParseUint(b, 10, 64)
+	switch {
+	case aErr == nil && bErr == nil:
+		if aNum > bNum {
+			return 1
+		}
+		if aNum < bNum {
+			return -1
+		}
+		return 0
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
 }
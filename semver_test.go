@@ -22,6 +22,21 @@ func Test_Compare(t *testing.T) {
 		{a: Version{major: 1, minor: 1, patch: 2}, b: Version{major: 1, minor: 1, patch: 1}, expected: 1},
 		{a: Version{major: 1, minor: 1, patch: 1}, b: Version{major: 1, minor: 1, patch: 2}, expected: -1},
 		{a: Version{major: 1, minor: 1, patch: 1}, b: Version{major: 1, minor: 1, patch: 1}, expected: 0},
+
+		// Pre-release precedence examples from the spec:
+		{a: Version{major: 1, pre: "alpha"}, b: Version{major: 1}, expected: -1},
+		{a: Version{major: 1}, b: Version{major: 1, pre: "alpha"}, expected: 1},
+		{a: Version{major: 1, pre: "alpha"}, b: Version{major: 1, pre: "alpha.1"}, expected: -1},
+		{a: Version{major: 1, pre: "alpha.1"}, b: Version{major: 1, pre: "alpha.beta"}, expected: -1},
+		{a: Version{major: 1, pre: "alpha.beta"}, b: Version{major: 1, pre: "beta"}, expected: -1},
+		{a: Version{major: 1, pre: "beta"}, b: Version{major: 1, pre: "beta.2"}, expected: -1},
+		{a: Version{major: 1, pre: "beta.2"}, b: Version{major: 1, pre: "beta.11"}, expected: -1},
+		{a: Version{major: 1, pre: "beta.11"}, b: Version{major: 1, pre: "rc.1"}, expected: -1},
+		{a: Version{major: 1, pre: "rc.1"}, b: Version{major: 1, pre: "rc.1"}, expected: 0},
+		{a: Version{major: 1, pre: "0"}, b: Version{major: 1, pre: "alpha"}, expected: -1},
+
+		// Build metadata is ignored:
+		{a: Version{major: 1, build: "a"}, b: Version{major: 1, build: "b"}, expected: 0},
 	}
 
 	for _, testCase := range testCases {
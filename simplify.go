@@ -0,0 +1,92 @@
+package semver
+
+import (
+	"sort"
+	"strings"
+)
+
+// Simplify returns the shortest range that is equivalent to the provided
+// range with respect to the provided list of versions, e.g. for the versions
+// `1.0.0`, `1.0.1`, `1.0.2`, `1.1.0`, and `1.2.0`, the range
+// `1.0.0 || 1.0.1 || 1.0.2 || 1.1.0` simplifies to `1.0.0 - 1.1.0`. This
+// mirrors the `simplifyRange` function of npm's `node-semver`.
+//
+// If the simplified range would not be shorter than the original text of the
+// range, as returned by [Range.Raw], or would not match exactly the same
+// versions from the list, the original range is returned.
+func Simplify(versions []*Version, r *Range) *Range {
+	sorted := make([]*Version, len(versions))
+	copy(sorted, versions)
+	sort.SliceStable(sorted, func(a int, b int) bool {
+		return sorted[a].Less(sorted[b]) == true
+	})
+
+	// Collect runs of consecutive versions that satisfy the range. A run
+	// without a last version continues through the end of the list.
+	type run struct {
+		first *Version
+		last  *Version
+	}
+	runs := make([]run, 0)
+	var first, previous *Version
+	for _, v := range sorted {
+		if v.Satisfies(r) == true {
+			previous = v
+			if first == nil {
+				first = v
+			}
+			continue
+		}
+		if previous != nil {
+			runs = append(runs, run{first: first, last: previous})
+		}
+		first = nil
+		previous = nil
+	}
+	if first != nil {
+		runs = append(runs, run{first: first})
+	}
+	if len(runs) == 0 {
+		// None of the versions satisfy the range, which we can not express
+		// any more succinctly.
+		return r
+	}
+
+	sets := make([]string, 0, len(runs))
+	for _, run := range runs {
+		switch {
+		case run.first == run.last:
+			sets = append(sets, rangeVersionString(run.first))
+		case run.last == nil && run.first == sorted[0]:
+			sets = append(sets, "*")
+		case run.last == nil:
+			sets = append(sets, ">="+rangeVersionString(run.first))
+		case run.first == sorted[0]:
+			sets = append(sets, "<="+rangeVersionString(run.last))
+		default:
+			sets = append(sets, rangeVersionString(run.first)+" - "+rangeVersionString(run.last))
+		}
+	}
+
+	simplified := strings.Join(sets, " || ")
+	if len(simplified) >= len(r.Raw()) {
+		return r
+	}
+
+	result, err := RangeFromStringWithOptions(simplified, r.options)
+	if err != nil {
+		return r
+	}
+	for _, v := range sorted {
+		if v.Satisfies(result) != v.Satisfies(r) {
+			return r
+		}
+	}
+	return result
+}
+
+// rangeVersionString renders the version for use in a range string. Build
+// metadata does not factor into precedence, so it is omitted.
+func rangeVersionString(v *Version) string {
+	return newVersion(v.major, v.minor, v.patch, v.pre).String()
+}
@@ -0,0 +1,66 @@
+package semver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSimplify(t *testing.T) {
+	versionStrings := []string{
+		"1.2.0", "1.0.0", "1.0.1", "1.0.2", "1.1.0", // out of order on purpose
+		"2.0.0", "2.0.1", "2.1.0", "3.0.0-beta.1", "3.0.0",
+	}
+	versions := make([]*Version, 0, len(versionStrings))
+	for _, s := range versionStrings {
+		v, _ := VersionFromString(s)
+		versions = append(versions, v)
+	}
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{input: "1.0.0 || 1.0.1 || 1.0.2 || 1.1.0", expected: "<=1.1.0"},
+		{input: "1.0.1 || 1.0.2 || 1.1.0 || 1.2.0", expected: ">=1.0.1 <=1.2.0"},
+		{input: "1.0.0 || 1.0.1 || 1.0.2 || 1.1.0 || 1.2.0", expected: "<=1.2.0"},
+		{input: "2.0.0 || 2.0.1 || 2.1.0 || 3.0.0", expected: ">=2.0.0 <=2.1.0 || >=3.0.0"},
+		{input: "1.0.0 || 1.0.1 || 1.0.2 || 1.1.0 || 2.0.0 || 2.0.1", expected: "<=1.1.0 || >=2.0.0 <=2.0.1"},
+		{input: "1.1.0 || 2.0.1", expected: "=1.1.0 || =2.0.1"},
+		{input: ">=1.0.0 <=1.2.0 || >=2.0.0 <=2.1.0 || 3.0.0", expected: "<=2.1.0 || >=3.0.0"},
+
+		{input: "~1.0.0 || ~1.1.0", expected: "<=1.1.0"},
+
+		// Already as simple as possible:
+		{input: "^1.0.0", expected: ">=1.0.0 <2.0.0-0"},
+		{input: "1.0.1", expected: "=1.0.1"},
+		{input: "*", expected: ">=0.0.0"},
+
+		// `>=2.0.0` would not match the pre-release:
+		{
+			input:    "2.0.0 || 2.0.1 || 2.1.0 || 3.0.0-beta.1 || 3.0.0",
+			expected: "=2.0.0 || =2.0.1 || =2.1.0 || =3.0.0-beta.1 || =3.0.0",
+		},
+
+		// Nothing matches:
+		{input: "^4.0.0", expected: ">=4.0.0 <5.0.0-0"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.input, func(t *testing.T) {
+			r, err := RangeFromString(testCase.input)
+			assert.Nil(t, err)
+
+			found := Simplify(versions, r)
+			assert.Equal(t, testCase.expected, found.String())
+			for _, v := range versions {
+				assert.Equal(t, v.Satisfies(r), v.Satisfies(found), v.String())
+			}
+		})
+	}
+
+	t.Run("does not reorder the provided versions", func(t *testing.T) {
+		r, _ := RangeFromString("1.0.0 || 1.0.1")
+		Simplify(versions, r)
+		assert.Equal(t, "1.2.0", versions[0].String())
+	})
+}
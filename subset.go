@@ -0,0 +1,363 @@
+package semver
+
+// IsSubsetOf determines if every version that satisfies the range also
+// satisfies the super range. In other words, it determines that narrowing a
+// constraint from `super` to `r` does not allow any new versions. This
+// follows the `subset` algorithm of npm's `node-semver`.
+//
+// The range is a subset when every comparator set in the range is a subset
+// of at least one comparator set in the super range. Comparator sets that
+// can never be satisfied are ignored, so a range that no version satisfies,
+// e.g. the empty result of [Range.Intersect], is a subset of any range.
+//
+// The npm algorithm has no notion of `!=` comparators. A set that contains
+// one, or any set of a super range that contains one, is instead compared
+// against the versions covered by the whole super range.
+//
+// Each range is evaluated with the options it was parsed with, as by
+// [Version.Satisfies]. For example, `^1.2.3-pre.0` is not a subset of `1.x`,
+// unless `1.x` was parsed with [Options.IncludePrerelease]. The same goes for
+// the pre-release rules of other dialects, e.g. the Composer range
+// `^1.2@dev` is not a subset of the Composer range `~1.2`, since only the
+// former is satisfied by `1.2.3-alpha`.
+func (r *Range) IsSubsetOf(super *Range) bool {
+	if r.options.IncludePrerelease != super.options.IncludePrerelease ||
+		r.hasPrereleasePolicy() == true || super.hasPrereleasePolicy() == true {
+		return versionsCoveredBy(r, super)
+	}
+
+	opts := r.options
+	superExcludes := false
+	for _, superSet := range super.comparators {
+		superExcludes = superExcludes || hasNotEqual(superSet)
+	}
+
+outer:
+	for _, set := range r.comparators {
+		if lowestWitness(opts, set) == nil {
+			// No version satisfies the set, e.g. `<0.0.0-0`, so there is
+			// nothing that the super range would need to cover.
+			continue
+		}
+
+		if superExcludes == true || hasNotEqual(set) == true {
+			if setCoveredBy(set, super, opts) == true {
+				continue
+			}
+			return false
+		}
+
+		for _, superSet := range super.comparators {
+			if isSimpleSubset(set, superSet, opts) == true {
+				continue outer
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+// isSimpleSubset determines if the versions allowed by the set are a
+// subset of the versions allowed by the super set. The set must be
+// satisfiable by at least one version.
+func isSimpleSubset(set ComparatorSet, superSet ComparatorSet, opts Options) bool {
+	comparators := set.comparators
+	superComparators := superSet.comparators
+
+	if isAnySet(comparators) == true {
+		if isAnySet(superComparators) == true {
+			return true
+		}
+		comparators = []*Comparator{minimumVersionComparator(opts)}
+	}
+	if isAnySet(superComparators) == true {
+		if opts.IncludePrerelease == true {
+			return true
+		}
+		superComparators = []*Comparator{minimumVersionComparator(opts)}
+	}
+
+	var gt, lt, eq *Comparator
+	for _, c := range comparators {
+		switch c.operator {
+		case OperatorGreaterThan, OperatorGreaterThanEqual:
+			gt = higherGreaterThan(gt, c)
+		case OperatorLessThan, OperatorLessThanEqual:
+			lt = lowerLessThan(lt, c)
+		default:
+			if eq != nil && eq.version.Equals(c.version) == false {
+				// Two different exact versions can never both be satisfied.
+				return false
+			}
+			eq = c
+		}
+	}
+
+	gtltCompare := 1
+	if gt != nil && lt != nil {
+		gtltCompare = gt.version.Compare(lt.version)
+		if gtltCompare > 0 {
+			return false
+		}
+		if gtltCompare == 0 &&
+			(gt.operator != OperatorGreaterThanEqual || lt.operator != OperatorLessThanEqual) {
+			return false
+		}
+	}
+
+	if eq != nil {
+		// The set can only ever match the one version.
+		if comparatorsSatisfiedBy(comparators, eq.version, opts) == false {
+			return false
+		}
+		return comparatorsSatisfiedBy(superComparators, eq.version, opts)
+	}
+
+	// If the bounds of the set include a pre-release, then the super set must
+	// opt in to pre-releases of the same tuple. Otherwise, the set would allow
+	// pre-releases the super set does not. The exception is `<1.2.3-0`, which
+	// is equivalent to `<1.2.3`.
+	var needSuperGTPre, needSuperLTPre *Version
+	if opts.IncludePrerelease == false {
+		if gt != nil && gt.version.pre != "" {
+			needSuperGTPre = gt.version
+		}
+		if lt != nil && lt.version.pre != "" &&
+			(lt.operator != OperatorLessThan || lt.version.pre != "0") {
+			needSuperLTPre = lt.version
+		}
+	}
+
+	hasSuperGT := false
+	hasSuperLT := false
+	for _, c := range superComparators {
+		isGT := c.operator == OperatorGreaterThan || c.operator == OperatorGreaterThanEqual
+		isLT := c.operator == OperatorLessThan || c.operator == OperatorLessThanEqual
+		hasSuperGT = hasSuperGT || isGT
+		hasSuperLT = hasSuperLT || isLT
+
+		if gt != nil {
+			if needSuperGTPre != nil && allowsPrerelease(c, needSuperGTPre) == true {
+				needSuperGTPre = nil
+			}
+			if isGT == true {
+				higher := higherGreaterThan(gt, c)
+				if higher == c && higher != gt {
+					return false
+				}
+			} else if gt.operator == OperatorGreaterThanEqual && inRange(gt.version, c) == false {
+				return false
+			}
+		}
+
+		if lt != nil {
+			if needSuperLTPre != nil && allowsPrerelease(c, needSuperLTPre) == true {
+				needSuperLTPre = nil
+			}
+			if isLT == true {
+				lower := lowerLessThan(lt, c)
+				if lower == c && lower != lt {
+					return false
+				}
+			} else if lt.operator == OperatorLessThanEqual && inRange(lt.version, c) == false {
+				return false
+			}
+		}
+
+		if c.operator == OperatorEqual && (lt != nil || gt != nil) && gtltCompare != 0 {
+			return false
+		}
+	}
+
+	// The set is unbounded in a direction that the super set is bounded.
+	if gt != nil && hasSuperLT == true && lt == nil && gtltCompare != 0 {
+		return false
+	}
+	if lt != nil && hasSuperGT == true && gt == nil && gtltCompare != 0 {
+		return false
+	}
+
+	if needSuperGTPre != nil || needSuperLTPre != nil {
+		return false
+	}
+
+	return true
+}
+
+// isAnySet determines if the comparators represent the "any version" range,
+// e.g. `*`.
+func isAnySet(comparators []*Comparator) bool {
+	if len(comparators) != 1 {
+		return false
+	}
+	c := comparators[0]
+	return c.operator == OperatorGreaterThanEqual &&
+		c.version.Equals(newVersion(0, 0, 0, "")) == true
+}
+
+// minimumVersionComparator returns the comparator that matches every
+// version allowed by the provided options.
+func minimumVersionComparator(opts Options) *Comparator {
+	if opts.IncludePrerelease == true {
+		return &Comparator{operator: OperatorGreaterThanEqual, version: newVersion(0, 0, 0, "0")}
+	}
+	return &Comparator{operator: OperatorGreaterThanEqual, version: newVersion(0, 0, 0, "")}
+}
+
+// higherGreaterThan returns the more restrictive of two lower bound
+// comparators. If `a` is `nil`, `b` is returned.
+func higherGreaterThan(a *Comparator, b *Comparator) *Comparator {
+	if a == nil {
+		return b
+	}
+	switch a.version.Compare(b.version) {
+	case 1:
+		return a
+	case -1:
+		return b
+	}
+	if b.operator == OperatorGreaterThan && a.operator == OperatorGreaterThanEqual {
+		return b
+	}
+	return a
+}
+
+// lowerLessThan returns the more restrictive of two upper bound
+// comparators. If `a` is `nil`, `b` is returned.
+func lowerLessThan(a *Comparator, b *Comparator) *Comparator {
+	if a == nil {
+		return b
+	}
+	switch a.version.Compare(b.version) {
+	case -1:
+		return a
+	case 1:
+		return b
+	}
+	if b.operator == OperatorLessThan && a.operator == OperatorLessThanEqual {
+		return b
+	}
+	return a
+}
+
+// hasNotEqual determines if the set contains a `!=` comparator.
+func hasNotEqual(set ComparatorSet) bool {
+	for _, c := range set.comparators {
+		if c.operator == OperatorNotEqual {
+			return true
+		}
+	}
+	return false
+}
+
+// setCoveredBy determines if every version that satisfies the set also
+// satisfies the super range, by comparing the intervals the two cover.
+func setCoveredBy(set ComparatorSet, super *Range, opts Options) bool {
+	return versionsCoveredBy(&Range{comparators: []ComparatorSet{set}, options: opts}, super)
+}
+
+// versionsCoveredBy determines if every version that satisfies the range
+// also satisfies the super range, regardless of the options and pre-release
+// policies the ranges are evaluated with. The releases covered by the range
+// must be covered by the super range, and so must the pre-releases that
+// satisfy the range. Pre-releases are compared once for every kind of
+// pre-release that the policies tell apart, e.g. the stabilities of
+// Composer, since a policy may allow `1.2.3-beta` but not `1.2.3-alpha`.
+func versionsCoveredBy(r *Range, super *Range) bool {
+	for _, gap := range intersectIntervals(r.Intervals(), complementIntervals(super.Intervals())) {
+		if containsRelease(gap) == true {
+			return false
+		}
+	}
+	for _, label := range prereleaseLabels(r, super) {
+		wanted := r.satisfyingPrereleases(label)
+		have := super.satisfyingPrereleases(label)
+		for _, gap := range intersectIntervals(wanted, complementIntervals(have)) {
+			if v := gap.single(); v != nil {
+				// The gap may only hold a pre-release of another kind,
+				// so ask the ranges about it directly.
+				if v.Satisfies(r) == true && v.Satisfies(super) == false {
+					return false
+				}
+				continue
+			}
+			if containsPrerelease(gap) == true {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// prereleaseLabels returns a pre-release label of every kind that the
+// pre-release policies of the ranges tell apart: those of the lowest
+// pre-releases each policy allows, and those named by the comparators. A
+// policy that allows every pre-release, or none, does not tell any apart,
+// which the label `0` stands in for.
+func prereleaseLabels(ranges ...*Range) []string {
+	result := []string{"0"}
+	add := func(label string) {
+		for _, existing := range result {
+			if existing == label {
+				return
+			}
+		}
+		result = append(result, label)
+	}
+	for _, r := range ranges {
+		for _, set := range r.evaluatedSets() {
+			for _, c := range set.comparators {
+				if c.version.pre != "" {
+					add(c.version.pre)
+				}
+			}
+			if set.prerelease == nil {
+				continue
+			}
+			for _, v := range set.prerelease.lowest(newVersion(0, 0, 0, "")) {
+				add(v.pre)
+			}
+		}
+	}
+	return result
+}
+
+// satisfyingPrereleases returns the intervals that contain the pre-releases
+// with the label's kind that satisfy the range, with its own options. They
+// may contain releases, and other kinds of pre-releases, as well.
+func (r *Range) satisfyingPrereleases(label string) []Interval {
+	result := make([]Interval, 0)
+	for _, set := range r.evaluatedSets() {
+		if set.prerelease == nil {
+			for _, tuple := range prereleaseTuples([]ComparatorSet{set}) {
+				result = append(result, prereleaseIntervals([]ComparatorSet{set}, tuple)...)
+			}
+			continue
+		}
+		for _, interval := range labeledSetIntervals(set, label) {
+			probe := newVersion(0, 0, 0, label)
+			if v := interval.single(); v != nil {
+				// A single version, e.g. the `= 1.2.3-beta` a HashiCorp
+				// policy allows, is asked about with its own tuple.
+				probe = newVersion(v.major, v.minor, v.patch, label)
+			}
+			if set.prerelease.allows(probe) == true {
+				result = append(result, interval)
+			}
+		}
+	}
+	return normalizeIntervals(result)
+}
+
+// hasPrereleasePolicy determines if any set of the range decides which
+// pre-releases satisfy it with a policy of its own, as the sets of most
+// dialects other than npm do.
+func (r *Range) hasPrereleasePolicy() bool {
+	for _, set := range r.comparators {
+		if set.prerelease != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package semver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRange_IsSubsetOf(t *testing.T) {
+	testCases := []struct {
+		sub       string
+		super     string
+		expected  bool
+		subOpts   Options
+		superOpts Options
+	}{
+		{sub: "1.2.3", super: "1.2.3", expected: true},
+		{sub: "^2.0.0 !=2.3.1", super: "^2.0.0", expected: true},
+		{sub: "^2.0.0", super: "^2.0.0 !=2.3.1", expected: false},
+		{sub: "~2.4.0", super: "^2.0.0 !=2.3.1", expected: true},
+		{sub: "^2.0.0 !=2.3.1", super: "<2.3.1 || >2.3.1 <3.0.0-0", expected: true},
+		{sub: "1.0.0 !=1.0.0", super: "2.0.0", expected: true},
+		{sub: "1.2.3", super: "1.x", expected: true},
+		{sub: "1.2.3 1.2.4", super: "1.2.3", expected: true},
+		{sub: "1.2.3", super: ">1.2.0", expected: true},
+		{sub: "1.2.3", super: "<1.2.0", expected: false},
+		{sub: ">2 <1", super: "3", expected: true},
+		{sub: "1 || 2 || 3", super: ">=1.0.0", expected: true},
+		{sub: "1 || 2 || 4", super: "1 || 2 || 3", expected: false},
+		{sub: "*", super: "*", expected: true},
+		{sub: "", super: "*", expected: true},
+		{sub: "*", super: ">=1.0.0", expected: false},
+		{sub: ">=1.0.0", super: "*", expected: true},
+		{sub: ">=1.0.0 <2.0.0", super: "<2.0.0", expected: true},
+		{sub: ">=1.0.0 <2.0.0", super: ">=1.0.0", expected: true},
+		{sub: "<2.0.0", super: ">=1.0.0 <2.0.0", expected: false},
+		{sub: ">=1.0.0", super: ">=1.0.0 <2.0.0", expected: false},
+		{sub: ">1.0.0", super: ">=1.0.0", expected: true},
+		{sub: ">=1.0.0", super: ">1.0.0", expected: false},
+		{sub: "<=2.0.0", super: "<2.0.0", expected: false},
+		{sub: ">=1.0.0 <=1.0.0", super: "1.0.0", expected: true},
+		{sub: "~1.2.3", super: "^1.2.0", expected: true},
+		{sub: "^1.2.0", super: "~1.2.3", expected: false},
+		{sub: "^1.2.3", super: "1.x || 2.x", expected: true},
+		{sub: "^2 || ^3 || ^4", super: ">=1.0.0", expected: true},
+		{sub: "1.0.0 - 2.0.0", super: ">=1.0.0 <2.0.1", expected: true},
+
+		// Pre-releases:
+		{sub: "^1.2.3-pre.0", super: "1.x", expected: false},
+		{sub: "^1.2.3-pre.0", super: "1.x", expected: true, subOpts: Options{IncludePrerelease: true}, superOpts: Options{IncludePrerelease: true}},
+		{sub: "^1.2.3-pre.1", super: "^1.2.3-pre.0", expected: true},
+		{sub: "^1.2.3-pre.0", super: "^1.2.3-pre.1", expected: false},
+		{sub: "^1.2.3-pre.1", super: ">=1.2.3-alpha <2.0.0-0", expected: true},
+		{sub: "<1.0.0-0", super: "<1.0.0", expected: true},
+		{sub: "1.2.3-beta", super: ">=1.0.0", expected: false},
+		{sub: "1.2.3-beta", super: ">=1.2.3-alpha <2.0.0", expected: true},
+		{sub: "1.2.3-beta", super: ">=1.0.0", expected: true, subOpts: Options{IncludePrerelease: true}, superOpts: Options{IncludePrerelease: true}},
+
+		// Ranges parsed with different options:
+		{sub: "^1.2.3-pre.0", super: "1.x", expected: true, superOpts: Options{IncludePrerelease: true}},
+		{sub: "^1.2.3-pre.0", super: "1.x", expected: false, subOpts: Options{IncludePrerelease: true}},
+		{sub: "^1.2.3-pre.0", super: ">=1.2.3-pre.0 <2.0.0-0", expected: false, subOpts: Options{IncludePrerelease: true}},
+		{sub: ">=1.2.3-pre.0 <1.2.3", super: ">=1.2.3-pre.0 <2.0.0-0", expected: true, subOpts: Options{IncludePrerelease: true}},
+		{sub: ">=1.2.0 <1.3.0", super: "~1.2.0", expected: false, subOpts: Options{IncludePrerelease: true}},
+		{sub: "1.2.3", super: "~1.2.0", expected: true, subOpts: Options{IncludePrerelease: true}},
+		{sub: "1.2.3-beta", super: "1.2.3-beta", expected: true, subOpts: Options{IncludePrerelease: true}},
+		{sub: "<1.0.0", super: "<2.0.0", expected: true, superOpts: Options{IncludePrerelease: true}},
+		{sub: "<2.0.0", super: "<1.0.0", expected: false, superOpts: Options{IncludePrerelease: true}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.sub+" within "+testCase.super, func(t *testing.T) {
+			sub, err := RangeFromStringWithOptions(testCase.sub, testCase.subOpts)
+			assert.Nil(t, err)
+			super, err := RangeFromStringWithOptions(testCase.super, testCase.superOpts)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, sub.IsSubsetOf(super))
+		})
+	}
+}
+
+func TestRange_IsSubsetOf_Empty(t *testing.T) {
+	r1, _ := RangeFromString("^1.0.0")
+	r2, _ := RangeFromString("^2.0.0")
+	empty := r1.Intersect(r2)
+	assert.Equal(t, true, empty.IsEmpty())
+	assert.Equal(t, true, empty.IsSubsetOf(r1))
+	assert.Equal(t, true, r1.Difference(r1).IsSubsetOf(r2))
+	assert.Equal(t, false, r1.IsSubsetOf(empty))
+}
+
+func TestRange_IsSubsetOf_Dialects(t *testing.T) {
+	testCases := []struct {
+		sub          string
+		subDialect   Dialect
+		super        string
+		superDialect Dialect
+		expected     bool
+	}{
+		// `1.2.3-alpha` is only allowed by the development stability.
+		{sub: "^1.2@dev", subDialect: DialectComposer, super: "~1.2", superDialect: DialectComposer, expected: false},
+		{sub: "^1.2@alpha", subDialect: DialectComposer, super: "^1.2@beta", superDialect: DialectComposer, expected: false},
+		{sub: "^1.2@beta", subDialect: DialectComposer, super: "^1.2@alpha", superDialect: DialectComposer, expected: true},
+		{sub: "1.0.0-beta1", subDialect: DialectComposer, super: ">=1.0@beta", superDialect: DialectComposer, expected: true},
+		// `1.2.3-p1` is as stable as a release to Composer.
+		{sub: "~1.2", subDialect: DialectComposer, super: ">=1.2.0 <2.0.0-0", superDialect: DialectNPM, expected: false},
+		// `1.2.4-0` satisfies any Masterminds range that names a pre-release.
+		{sub: "^1.2.3-beta", subDialect: DialectMasterminds, super: "^1.2.3-beta", superDialect: DialectCargo, expected: false},
+		{sub: "^1.2.3-beta", subDialect: DialectCargo, super: "^1.2.3-beta", superDialect: DialectMasterminds, expected: true},
+		{sub: "!=1.2.3-beta", subDialect: DialectMasterminds, super: "*", superDialect: DialectNPM, expected: false},
+		// A partial `!=` only excludes the versions with its own pre-release.
+		{sub: ">=1.2.0-alpha <1.2.0", subDialect: DialectNPM, super: ">=1.2.0-0, !=1.2-beta", superDialect: DialectMasterminds, expected: false},
+		{sub: ">=1.2.5-beta <1.2.5", subDialect: DialectNPM, super: ">=1.2.5-beta, !=1.2", superDialect: DialectMasterminds, expected: true},
+		// `0.0.4-0` satisfies any interval that covers it.
+		{sub: "1.0", subDialect: DialectMaven, super: "*", superDialect: DialectComposer, expected: false},
+		{sub: "[1.0,2.0)", subDialect: DialectMaven, super: "[1.0,3.0)", superDialect: DialectNuGet, expected: true},
+		// HashiCorp only allows a pre-release that an exact comparator names.
+		{sub: "1.0.0-beta1", subDialect: DialectComposer, super: ">= 1.0.0-beta", superDialect: DialectHashiCorp, expected: false},
+		{sub: "= 1.2.3-beta", subDialect: DialectHashiCorp, super: "^1.2@beta", superDialect: DialectComposer, expected: true},
+		{sub: "~> 1.2", subDialect: DialectHashiCorp, super: "~> 1.2", superDialect: DialectRubyGems, expected: true},
+	}
+
+	for _, testCase := range testCases {
+		name := testCase.subDialect.String() + " " + testCase.sub + " within " + testCase.superDialect.String() + " " + testCase.super
+		t.Run(name, func(t *testing.T) {
+			sub, err := ParseRange(testCase.sub, testCase.subDialect)
+			assert.Nil(t, err)
+			super, err := ParseRange(testCase.super, testCase.superDialect)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, sub.IsSubsetOf(super))
+		})
+	}
+}
@@ -41,6 +41,20 @@ const (
 	parsingBuild
 )
 
+// newVersion builds a complete version, i.e. one that is not partial, from
+// the provided components.
+func newVersion(major int, minor int, patch int, pre string) *Version {
+	return &Version{
+		major:       major,
+		minor:       minor,
+		patch:       patch,
+		majorParsed: true,
+		minorParsed: true,
+		patchParsed: true,
+		pre:         pre,
+	}
+}
+
 func VersionFromString(input string) (*Version, error) {
 	return VersionFromBytes([]byte(input))
 }
@@ -231,40 +245,76 @@ func (v *Version) LessThanEquals(ver *Version) bool {
 
 // Satisfies determines if the version is covered by the provided
 // [Range].
+//
+// A version with a pre-release tag, e.g. `1.2.3-beta.1`, only satisfies a
+// comparator set when at least one comparator in the set targets the same
+// `[major, minor, patch]` tuple and also has a pre-release tag. This matches
+// the behavior of npm's `node-semver`, and keeps consumers of a range like
+// `^1.2.3` from unexpectedly receiving unstable versions. The rule is
+// disabled for a range parsed with [Options.IncludePrerelease].
 func (v *Version) Satisfies(r *Range) bool {
-	var result bool
+	return v.SatisfiesWithOptions(r, r.options)
+}
 
+// SatisfiesWithOptions is the same as [Version.Satisfies] with the
+// evaluation altered by the provided [Options].
+func (v *Version) SatisfiesWithOptions(r *Range, opts Options) bool {
 	for _, set := range r.comparators {
-		if result == true {
-			// We've iterated through at least one comparator set and the outcome
-			// was that the version satisfies the range defined by that set. According
-			// to the spec, this means the condition has been satisfied regardless
-			// of what any other set in the range would indicate.
-			break
+		// According to the spec, a version satisfies the range as soon as it
+		// satisfies any one of the sets in the range, regardless of what any
+		// other set in the range would indicate.
+		if setSatisfiedBy(set, v, opts) == true {
+			return true
 		}
+	}
+	return false
+}
 
-		if set.one != nil && set.two != nil {
-			a := inRange(v, set.one)
-			b := inRange(v, set.two)
-			result = a == true && b == true
-			continue
+// setSatisfiedBy determines if the version satisfies every comparator in
+// the set, while also honoring the pre-release rule described by
+// [Version.Satisfies], or the pre-release policy of the set.
+func setSatisfiedBy(set ComparatorSet, v *Version, opts Options) bool {
+	if set.prerelease == nil || v.pre == "" || opts.IncludePrerelease == true {
+		return comparatorsSatisfiedBy(set.comparators, v, opts)
+	}
+	for _, c := range set.comparators {
+		if inRange(v, c) == false {
+			return false
 		}
+	}
+	return set.prerelease.allows(v)
+}
 
-		if set.one != nil {
-			a := inRange(v, set.one)
-			if a == true {
-				result = a
-			}
+// comparatorsSatisfiedBy is the same as [setSatisfiedBy] for a list of
+// comparators.
+func comparatorsSatisfiedBy(comparators []*Comparator, v *Version, opts Options) bool {
+	for _, c := range comparators {
+		if inRange(v, c) == false {
+			return false
 		}
-		if set.two != nil {
-			b := inRange(v, set.two)
-			if b == true {
-				result = b
-			}
+	}
+	if v.pre == "" || opts.IncludePrerelease == true {
+		return true
+	}
+	for _, c := range comparators {
+		if allowsPrerelease(c, v) == true {
+			return true
 		}
 	}
+	return false
+}
 
-	return result
+// allowsPrerelease determines if the comparator opts in to pre-release
+// versions of the same `[major, minor, patch]` tuple as the version. A `!=`
+// comparator excludes a version rather than asking for it, so it never opts
+// in to pre-releases.
+func allowsPrerelease(c *Comparator, v *Version) bool {
+	if c.version.pre == "" || c.operator == OperatorNotEqual {
+		return false
+	}
+	return c.version.major == v.major &&
+		c.version.minor == v.minor &&
+		c.version.patch == v.patch
 }
 
 func inRange(ver *Version, comp *Comparator) bool {
@@ -280,7 +330,29 @@ func inRange(ver *Version, comp *Comparator) bool {
 		return compareResult == 1
 	case OperatorGreaterThanEqual:
 		return compareResult == 1 || compareResult == 0
+	case OperatorNotEqual:
+		if comp.excludesSpan() == true {
+			return inExcludedSpan(ver, comp.version) == false
+		}
+		return compareResult != 0
 	default:
 		return false
 	}
 }
+
+// inExcludedSpan determines if the version is within the span excluded by a
+// `!=` with the partial version, following Masterminds/semver. A missing
+// minor component excludes every version of the major, e.g. `!=1` excludes
+// `1.5.0-beta`. A missing patch component excludes the versions of the
+// major and minor with the same pre-release as the partial version, e.g.
+// `!=1.2` excludes `1.2.5` but not `1.2.5-beta`, and `!=1.2-beta` excludes
+// `1.2.5-beta` but not `1.2.5`.
+func inExcludedSpan(ver *Version, partial *Version) bool {
+	if ver.major != partial.major {
+		return false
+	}
+	if partial.minorParsed == false {
+		return true
+	}
+	return ver.minor == partial.minor && comparePre(ver.pre, partial.pre) == 0
+}
@@ -282,6 +282,42 @@ func TestVersion_Satisfies(t *testing.T) {
 			version:     "1.1.0",
 			targetRange: "=1.0.0",
 		},
+		{
+			title:       "not equal (true)",
+			expected:    true,
+			version:     "2.3.2",
+			targetRange: "^2.0.0 !=2.3.1",
+		},
+		{
+			title:       "not equal (false)",
+			expected:    false,
+			version:     "2.3.1",
+			targetRange: "^2.0.0 !=2.3.1",
+		},
+		{
+			title:       "not equal partial excludes the span (false)",
+			expected:    false,
+			version:     "1.2.5",
+			targetRange: "^1 !=1.2",
+		},
+		{
+			title:       "not equal partial excludes the span (true)",
+			expected:    true,
+			version:     "1.3.0",
+			targetRange: "^1 !=1.2",
+		},
+		{
+			title:       "not equal any excludes every version",
+			expected:    false,
+			version:     "1.0.0",
+			targetRange: "!=*",
+		},
+		{
+			title:       "not equal does not opt in to pre-releases",
+			expected:    false,
+			version:     "2.3.1-beta.2",
+			targetRange: "^2.0.0 !=2.3.1-beta.1",
+		},
 		{
 			title:       "less than (true)",
 			expected:    true,
@@ -387,6 +423,61 @@ func TestVersion_Satisfies(t *testing.T) {
 			version:     "4",
 			targetRange: "=1.0.0 || =2.0.0 || =3.0.0",
 		},
+
+		{
+			title:       "pre-release: caret allows same tuple pre-release",
+			expected:    true,
+			version:     "1.2.3-beta.4",
+			targetRange: "^1.2.3-beta.2",
+		},
+		{
+			title:       "pre-release: caret rejects lower pre-release",
+			expected:    false,
+			version:     "1.2.3-beta.1",
+			targetRange: "^1.2.3-beta.2",
+		},
+		{
+			title:       "pre-release: caret rejects other tuple pre-release",
+			expected:    false,
+			version:     "1.2.4-beta.2",
+			targetRange: "^1.2.3-beta.2",
+		},
+		{
+			title:       "pre-release: caret allows release",
+			expected:    true,
+			version:     "1.9.0",
+			targetRange: "^1.2.3-beta.2",
+		},
+		{
+			title:       "pre-release: caret rejects next major pre-release",
+			expected:    false,
+			version:     "2.0.0-0",
+			targetRange: "^1.2.3-beta.2",
+		},
+		{
+			title:       "pre-release: tilde allows same tuple pre-release",
+			expected:    true,
+			version:     "1.2.3-rc.1",
+			targetRange: "~1.2.3-beta.2",
+		},
+		{
+			title:       "pre-release: tilde rejects next minor",
+			expected:    false,
+			version:     "1.3.0",
+			targetRange: "~1.2.3-beta.2",
+		},
+		{
+			title:       "pre-release: range without pre-release rejects it",
+			expected:    false,
+			version:     "1.5.0-beta",
+			targetRange: "^1.2.3",
+		},
+		{
+			title:       "pre-release: caret 0.0.x",
+			expected:    true,
+			version:     "0.0.3",
+			targetRange: "^0.0.3-beta",
+		},
 	}
 
 	for _, testCase := range testCases {